@@ -1,29 +1,122 @@
 package main
 
 import (
+	"bufio"
+	"flag"
 	"fmt"
+	"os"
 	"strings"
 	"tim/forth/core"
+	"tim/forth/core/logging"
+	"tim/forth/core/words"
 	io "tim/forth/io/commandline"
 )
 
 type handler struct {
 	interpreter *core.ForthInterpreter
+	history     []*core.Snapshot
 }
 
-func (h handler) Execute(command string) string {
-	splits := strings.Split(command, " ")
-	for _, v := range splits {
-		h.interpreter.Execute(v)
+func (h *handler) undo() string {
+	if len(h.history) == 0 {
+		return "Nothing to undo"
+	}
+
+	last := len(h.history) - 1
+	h.interpreter.Restore(h.history[last])
+	h.history = h.history[:last]
+
+	return "Undone!"
+}
+
+func (h *handler) Execute(command string) string {
+	if strings.TrimSpace(command) == "undo" {
+		return h.undo()
+	}
+
+	h.history = append(h.history, h.interpreter.Snapshot())
+
+	err := h.interpreter.Evaluate(command)
+	if err != nil {
+		return fmt.Sprintf("Error: %s", err.Error())
 	}
 
 	return "Consider it handled!"
 }
 
+var rollbackModes = map[string]core.RollbackMode{
+	"none": core.NoRollback,
+	"word": core.RollbackWord,
+	"line": core.RollbackLine,
+}
+
+func semantics(standard bool) words.Semantics {
+	if standard {
+		return words.StandardSemantics
+	}
+
+	return words.LegacySemantics
+}
+
+func loadImage(interpreter *core.ForthInterpreter, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return interpreter.LoadImage(file)
+}
+
+func division(floored bool) words.Division {
+	if floored {
+		return words.FlooredDivision
+	}
+
+	return words.SymmetricDivision
+}
+
 func main() {
+	logLevel := flag.String("log", "silent", "diagnostic log level: silent, info, debug or trace")
+	imagePath := flag.String("image", "", "an image saved with save-image to load before starting")
+	rollback := flag.String("rollback", "line", "what a failure undoes on the stack: none, word or line")
+	standard := flag.Bool("standard", false, "use standard Forth flags (-1/0), comparisons, IF and printing")
+	floored := flag.Bool("floored", false, "round division down rather than towards zero")
+	flag.Parse()
+
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	rollbackMode, found := rollbackModes[*rollback]
+	if !found {
+		fmt.Fprintf(os.Stderr, "unknown rollback mode [%s]\n", *rollback)
+		os.Exit(2)
+	}
+
 	fmt.Println("hi")
 
+	reader := bufio.NewReader(os.Stdin)
+	interpreter := core.NewForthInterpreter(
+		core.WithInput(reader),
+		core.WithLogger(logging.NewLogger(os.Stderr, level)),
+		core.WithDebugController(io.NewDebugController(reader, os.Stdout)),
+		core.WithRollback(rollbackMode),
+		core.WithSemantics(semantics(*standard)),
+		core.WithDivision(division(*floored)),
+	)
+
+	if *imagePath != "" {
+		err = loadImage(interpreter, *imagePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load image [%s]: %s\n", *imagePath, err.Error())
+			os.Exit(1)
+		}
+	}
+
 	io.CommandLineSource(&handler{
-		interpreter: core.NewForthInterpreter(),
-	})
+		interpreter: interpreter,
+	}, reader)
 }
@@ -0,0 +1,132 @@
+package compiler
+
+import (
+	"fmt"
+	"tim/forth/core/support/stacks"
+	"tim/forth/core/words"
+)
+
+type beginEnding int
+
+const (
+	endsWithUntil beginEnding = iota
+	endsWithAgain
+	endsWithRepeat
+)
+
+type beginAccumulator struct {
+	label      string
+	identifier string
+	utils      consumerUtils
+
+	// body is everything before WHILE, or the whole loop when there is no WHILE.
+	body      ExpressionQueue
+	whileBody ExpressionQueue
+	hasWhile  bool
+
+	ending     beginEnding
+	isComplete bool
+}
+
+func (acc *beginAccumulator) push(word string, resultHandler ExpressionPushHandler) {
+	complete := func(ending beginEnding) {
+		acc.ending = ending
+		acc.isComplete = true
+		resultHandler.onComplete()
+	}
+
+	switch {
+	case acc.utils.isUntil(word) && !acc.hasWhile:
+		complete(endsWithUntil)
+	case acc.utils.isAgain(word) && !acc.hasWhile:
+		complete(endsWithAgain)
+	case acc.utils.isWhile(word) && !acc.hasWhile:
+		acc.hasWhile = true
+	case acc.utils.isRepeat(word) && acc.hasWhile:
+		complete(endsWithRepeat)
+	case acc.utils.isControlWord(word):
+		resultHandler.onRejected()
+	case acc.hasWhile:
+		acc.whileBody.Enqueue(word)
+	default:
+		acc.body.Enqueue(word)
+	}
+}
+
+// stepName is the word run after the body, it decides whether to go round again.
+func (acc *beginAccumulator) stepName() string {
+	return fmt.Sprintf("%s:begin", acc.label)
+}
+
+func popFlag(ctx *words.ExecutionContext) (bool, error) {
+	if ctx.Stack.IsEmpty() {
+		return false, words.NewUnderflowError()
+	}
+
+	return ctx.IsTrue(ctx.Stack.Pop().ValueOf()), nil
+}
+
+func (acc *beginAccumulator) start(body []string) words.Word {
+	return func(ctx *words.ExecutionContext, executionStack stacks.StringStack) error {
+		pushBody(executionStack, acc.stepName(), body)
+		return nil
+	}
+}
+
+func (acc *beginAccumulator) step(body []string, whileBody []string) words.Word {
+	return func(ctx *words.ExecutionContext, executionStack stacks.StringStack) error {
+		if acc.ending == endsWithAgain {
+			pushBody(executionStack, acc.stepName(), body)
+			return nil
+		}
+
+		flag, err := popFlag(ctx)
+		if err != nil {
+			return err
+		}
+
+		if acc.ending == endsWithUntil {
+			if !flag {
+				pushBody(executionStack, acc.stepName(), body)
+			}
+			return nil
+		}
+
+		if flag {
+			pushBody(executionStack, acc.label, whileBody)
+		}
+		return nil
+	}
+}
+
+func (acc *beginAccumulator) attemptComplete(handler CompletionHandler) {
+	if !acc.isComplete {
+		handler.onError("Can not complete a begin loop without an until, again or repeat")
+		return
+	}
+
+	body := reverse(toSlice(acc.body))
+	whileBody := reverse(toSlice(acc.whileBody))
+
+	handler.onNativeComplete(acc.label, acc.start(body))
+	handler.onNativeComplete(acc.stepName(), acc.step(body, whileBody))
+}
+func (acc *beginAccumulator) toString() string {
+	return fmt.Sprintf("[%s] -> [BEGIN] %s [WHILE] %s \nisComplete -> %t\n", acc.name(), acc.body.ToString(), acc.whileBody.ToString(), acc.isComplete)
+}
+func (acc *beginAccumulator) name() string {
+	return acc.label
+}
+func (acc *beginAccumulator) id() string {
+	return acc.identifier
+}
+
+func NewBeginExpressionAccumulator(id string) ExpressionAccumulator {
+	return &beginAccumulator{
+		identifier: id,
+		label:      id,
+		utils:      consumerUtils{},
+		body:       NewExpressionQueue(),
+		whileBody:  NewExpressionQueue(),
+	}
+}
@@ -0,0 +1,154 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+	"tim/forth/core/support/stacks"
+	"tim/forth/core/words"
+)
+
+func (util consumerUtils) isOf(s string) bool {
+	return strings.ToLower(s) == "of"
+}
+func (util consumerUtils) isEndOf(s string) bool {
+	return strings.ToLower(s) == "endof"
+}
+func (util consumerUtils) isEndCase(s string) bool {
+	return strings.ToLower(s) == "endcase"
+}
+
+// caseClause is one `test OF body ENDOF`, test leaving the value to compare the
+// selector with.
+type caseClause struct {
+	test ExpressionQueue
+	body ExpressionQueue
+}
+
+type caseAccumulator struct {
+	label      string
+	identifier string
+	utils      consumerUtils
+
+	clauses []*caseClause
+	// pending collects the words after the last ENDOF, they become the next
+	// clause's test at OF or the default clause at ENDCASE.
+	pending  ExpressionQueue
+	inClause bool
+
+	isComplete bool
+}
+
+func (acc *caseAccumulator) push(word string, resultHandler ExpressionPushHandler) {
+	switch {
+	case acc.utils.isOf(word) && !acc.inClause:
+		acc.clauses = append(acc.clauses, &caseClause{
+			test: acc.pending,
+			body: NewExpressionQueue(),
+		})
+		acc.pending = NewExpressionQueue()
+		acc.inClause = true
+	case acc.utils.isEndOf(word) && acc.inClause:
+		acc.inClause = false
+	case acc.utils.isEndCase(word) && !acc.inClause:
+		acc.isComplete = true
+		resultHandler.onComplete()
+	case acc.utils.isControlWord(word):
+		resultHandler.onRejected()
+	case acc.inClause:
+		acc.clauses[len(acc.clauses)-1].body.Enqueue(word)
+	default:
+		acc.pending.Enqueue(word)
+	}
+}
+
+// clauseName is the word that compares the selector with clause index's test
+// value, running its body on a match and moving on to the next clause otherwise.
+func (acc *caseAccumulator) clauseName(index int) string {
+	return fmt.Sprintf("%s:of%d", acc.label, index)
+}
+func (acc *caseAccumulator) endName() string {
+	return fmt.Sprintf("%s:endcase", acc.label)
+}
+
+// next pushes the test for clause index, or the default clause once every
+// clause has been tried.
+func (acc *caseAccumulator) next(executionStack stacks.StringStack, index int, tests [][]string, defaultBody []string) {
+	if index < len(tests) {
+		pushBody(executionStack, acc.clauseName(index), tests[index])
+		return
+	}
+
+	pushBody(executionStack, acc.endName(), defaultBody)
+}
+
+func (acc *caseAccumulator) of(index int, tests [][]string, bodies [][]string, defaultBody []string) words.Word {
+	return func(ctx *words.ExecutionContext, executionStack stacks.StringStack) error {
+		if ctx.Stack.Size() < 2 {
+			return words.NewUnderflowError()
+		}
+
+		value := ctx.Stack.Pop()
+		if value.ValueOf() != ctx.Stack.Peek().ValueOf() {
+			acc.next(executionStack, index+1, tests, defaultBody)
+			return nil
+		}
+
+		ctx.Stack.Pop()
+		for _, v := range bodies[index] {
+			executionStack.Push(v)
+		}
+
+		return nil
+	}
+}
+
+func (acc *caseAccumulator) attemptComplete(handler CompletionHandler) {
+	if !acc.isComplete {
+		handler.onError("Can not complete a case without an endcase")
+		return
+	}
+
+	tests := make([][]string, len(acc.clauses))
+	bodies := make([][]string, len(acc.clauses))
+	for index, clause := range acc.clauses {
+		tests[index] = reverse(toSlice(clause.test))
+		bodies[index] = reverse(toSlice(clause.body))
+	}
+	defaultBody := reverse(toSlice(acc.pending))
+
+	handler.onNativeComplete(acc.label, func(ctx *words.ExecutionContext, executionStack stacks.StringStack) error {
+		acc.next(executionStack, 0, tests, defaultBody)
+		return nil
+	})
+	for index := range acc.clauses {
+		handler.onNativeComplete(acc.clauseName(index), acc.of(index, tests, bodies, defaultBody))
+	}
+	// The default clause runs with the selector still on the stack, ENDCASE
+	// drops it.
+	handler.onNativeComplete(acc.endName(), func(ctx *words.ExecutionContext, executionStack stacks.StringStack) error {
+		if ctx.Stack.IsEmpty() {
+			return words.NewUnderflowError()
+		}
+
+		ctx.Stack.Pop()
+		return nil
+	})
+}
+func (acc *caseAccumulator) toString() string {
+	return fmt.Sprintf("[%s] -> [CASE] %d clauses, default %s \nisComplete -> %t\n", acc.name(), len(acc.clauses), acc.pending.ToString(), acc.isComplete)
+}
+func (acc *caseAccumulator) name() string {
+	return acc.label
+}
+func (acc *caseAccumulator) id() string {
+	return acc.identifier
+}
+
+func NewCaseExpressionAccumulator(id string) ExpressionAccumulator {
+	return &caseAccumulator{
+		identifier: id,
+		label:      id,
+		utils:      consumerUtils{},
+		pending:    NewExpressionQueue(),
+	}
+}
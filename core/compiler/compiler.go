@@ -3,16 +3,31 @@ package compiler
 import (
 	"fmt"
 	"strings"
+	"tim/forth/core/logging"
 	"tim/forth/core/support/queues"
 	"tim/forth/core/support/queues/core"
 	"tim/forth/core/support/stacks"
+	"tim/forth/core/words"
 )
 
 type AddResult int
 
+type CompilationError struct {
+	message string
+}
+
+func (e *CompilationError) Error() string {
+	return fmt.Sprintf("Compilation error: %s", e.message)
+}
+func NewCompilationError(message string) error {
+	return &CompilationError{
+		message: message,
+	}
+}
+
 type ForthCompiler interface {
 	PushWord(word string) error
-	Complete() (map[string]func(*stacks.ForthStack, stacks.StringStack) error, error)
+	Complete() (map[string]words.Word, error)
 }
 
 type ExpressionPushHandler interface {
@@ -21,7 +36,7 @@ type ExpressionPushHandler interface {
 	onError(error)
 }
 type CompletionHandler interface {
-	onNativeComplete(string, func(*stacks.ForthStack, stacks.StringStack) error)
+	onNativeComplete(string, words.Word)
 	onError(string)
 }
 type ExpressionAccumulator interface {
@@ -145,6 +160,33 @@ func (util consumerUtils) isThen(s string) bool {
 func (util consumerUtils) isElse(s string) bool {
 	return strings.ToLower(s) == "else"
 }
+func (util consumerUtils) isLoop(s string) bool {
+	return strings.ToLower(s) == "loop"
+}
+func (util consumerUtils) isPlusLoop(s string) bool {
+	return strings.ToLower(s) == "+loop"
+}
+func (util consumerUtils) isUntil(s string) bool {
+	return strings.ToLower(s) == "until"
+}
+func (util consumerUtils) isAgain(s string) bool {
+	return strings.ToLower(s) == "again"
+}
+func (util consumerUtils) isWhile(s string) bool {
+	return strings.ToLower(s) == "while"
+}
+func (util consumerUtils) isRepeat(s string) bool {
+	return strings.ToLower(s) == "repeat"
+}
+
+// isControlWord is true for the words that continue or close an expression,
+// these are only valid inside the expression they belong to.
+func (util consumerUtils) isControlWord(s string) bool {
+	return util.isThen(s) || util.isElse(s) ||
+		util.isLoop(s) || util.isPlusLoop(s) ||
+		util.isUntil(s) || util.isAgain(s) || util.isWhile(s) || util.isRepeat(s) ||
+		util.isOf(s) || util.isEndOf(s) || util.isEndCase(s)
+}
 
 type elseConsumer struct {
 	utils consumerUtils
@@ -156,7 +198,7 @@ func (consumer elseConsumer) consume(token string, accume *ifAccumulator, onReje
 		return
 	}
 
-	if consumer.utils.isElse(token) {
+	if consumer.utils.isControlWord(token) {
 		onReject()
 		return
 	}
@@ -181,6 +223,11 @@ func (consumer ifConsumer) consume(token string, accume *ifAccumulator, onReject
 		return
 	}
 
+	if consumer.utils.isControlWord(token) {
+		onReject()
+		return
+	}
+
 	accume.ifBody.Enqueue(token)
 }
 
@@ -207,13 +254,18 @@ func (acc *ifAccumulator) attemptComplete(handler CompletionHandler) {
 		ifList := reverse(toSlice(acc.ifBody))
 		elseList := reverse(toSlice(acc.elseBody))
 
-		handler.onNativeComplete(acc.label, func(forthStack *stacks.ForthStack, executionStack stacks.StringStack) error {
+		handler.onNativeComplete(acc.label, func(ctx *words.ExecutionContext, executionStack stacks.StringStack) error {
+			forthStack := ctx.Stack
 			if forthStack.IsEmpty() {
-				fmt.Println("Underflow....")
-				return nil
+				return words.NewUnderflowError()
 			}
 
-			if forthStack.Peek().ValueOf() == 0 {
+			flag := forthStack.Peek().ValueOf()
+			if ctx.Standard() {
+				forthStack.Pop()
+			}
+
+			if ctx.IsTrue(flag) {
 				for _, v := range ifList {
 					executionStack.Push(v)
 				}
@@ -225,6 +277,7 @@ func (acc *ifAccumulator) attemptComplete(handler CompletionHandler) {
 
 			return nil
 		})
+		return
 	}
 	handler.onError("Can not complete an if handler without a then")
 }
@@ -333,7 +386,8 @@ func NewExpressionQueue() ExpressionQueue {
 }
 
 type pushHandler struct {
-	c *forthCompiler
+	c    *forthCompiler
+	word string
 }
 
 func (h *pushHandler) onComplete() {
@@ -342,7 +396,7 @@ func (h *pushHandler) onComplete() {
 
 	poppedExpressionId := c.expressionIdStack.Pop()
 	poppedExpression := c.expressionMap[poppedExpressionId]
-	fmt.Println(poppedExpression.toString())
+	c.logger.Trace("Completed expression %s", poppedExpression.toString())
 
 	c.currentExpression = poppedExpression
 	c.currentExpression.push(referenceWord, h)
@@ -351,16 +405,19 @@ func (h *pushHandler) onComplete() {
 func (h *pushHandler) onError(e error) {
 
 }
-func (h *pushHandler) onRejected() {}
+func (h *pushHandler) onRejected() {
+	h.c.err = NewCompilationError(fmt.Sprintf("[%s] does not belong in %s", h.word, h.c.currentExpression.toString()))
+}
 
-func NewResultHandler(c *forthCompiler) ExpressionPushHandler {
+func NewResultHandler(c *forthCompiler, word string) ExpressionPushHandler {
 	return &pushHandler{
-		c: c,
+		c:    c,
+		word: word,
 	}
 }
 
-func wrapPredefined(name string, body []string) func(*stacks.ForthStack, stacks.StringStack) error {
-	return func(forthStack *stacks.ForthStack, executionStack stacks.StringStack) error {
+func wrapPredefined(name string, body []string) words.Word {
+	return func(ctx *words.ExecutionContext, executionStack stacks.StringStack) error {
 		for _, w := range body {
 			executionStack.Push(w)
 		}
@@ -371,13 +428,13 @@ func wrapPredefined(name string, body []string) func(*stacks.ForthStack, stacks.
 type completionResult struct {
 	hasError        bool
 	errorMessage    string
-	nativeFunctions map[string]func(*stacks.ForthStack, stacks.StringStack) error
+	nativeFunctions map[string]words.Word
 }
 
 func NewCompletionResult() *completionResult {
 	return &completionResult{
 		hasError:        false,
-		nativeFunctions: make(map[string]func(*stacks.ForthStack, stacks.StringStack) error),
+		nativeFunctions: make(map[string]words.Word),
 	}
 }
 
@@ -386,9 +443,10 @@ type completionHandler struct {
 }
 
 func (h *completionHandler) onError(message string) {
-
+	h.c.hasError = true
+	h.c.errorMessage = message
 }
-func (h *completionHandler) onNativeComplete(label string, nativeFunc func(*stacks.ForthStack, stacks.StringStack) error) {
+func (h *completionHandler) onNativeComplete(label string, nativeFunc words.Word) {
 	h.c.nativeFunctions[label] = nativeFunc
 }
 
@@ -399,15 +457,31 @@ func NewCompletionHandler(c *completionResult) CompletionHandler {
 }
 
 type forthCompiler struct {
+	logger            logging.Logger
 	idGenerator       InternalIdProvider
 	currentExpression ExpressionAccumulator
 	expressionIdStack stacks.StringStack
 	expressionMap     map[string]ExpressionAccumulator
+	err               error
 }
 
 func (c *forthCompiler) PushWord(word string) error {
-	if strings.ToLower(word) == "if" {
-		exp := NewIfExpressionAccumulator(c.idGenerator.NextId())
+	if c.err != nil {
+		return c.err
+	}
+
+	if (consumerUtils{}).isDoes(word) {
+		return c.startDoes()
+	}
+
+	if c.expressionIdStack.IsEmpty() && (consumerUtils{}).isControlWord(word) {
+		c.err = NewCompilationError(fmt.Sprintf("[%s] without a matching opening word", word))
+		return c.err
+	}
+
+	opener, found := expressionOpeners()[strings.ToLower(word)]
+	if found {
+		exp := opener(c.idGenerator.NextId())
 
 		c.expressionIdStack.Push(c.currentExpression.id())
 
@@ -417,19 +491,50 @@ func (c *forthCompiler) PushWord(word string) error {
 		return nil
 	}
 
-	c.currentExpression.push(word, NewResultHandler(c))
-	return nil
+	c.currentExpression.push(word, NewResultHandler(c, word))
+	return c.err
 }
 
-func (c *forthCompiler) Complete() (map[string]func(*stacks.ForthStack, stacks.StringStack) error, error) {
+// IsCompilerWord is true for the words the compiler itself handles, such as IF
+// and LOOP, rather than ones looked up when the definition runs.
+func IsCompilerWord(word string) bool {
+	utils := consumerUtils{}
+	_, opener := expressionOpeners()[strings.ToLower(word)]
+
+	return opener || utils.isControlWord(word) || utils.isDoes(word)
+}
+
+// expressionOpeners are the words that start a new expression, keyed by their
+// lower case name.
+func expressionOpeners() map[string]func(id string) ExpressionAccumulator {
+	openers := make(map[string]func(id string) ExpressionAccumulator)
+
+	openers["if"] = NewIfExpressionAccumulator
+	openers["do"] = func(id string) ExpressionAccumulator {
+		return NewDoExpressionAccumulator(id, false)
+	}
+	openers["?do"] = func(id string) ExpressionAccumulator {
+		return NewDoExpressionAccumulator(id, true)
+	}
+	openers["begin"] = NewBeginExpressionAccumulator
+	openers["case"] = NewCaseExpressionAccumulator
+
+	return openers
+}
+
+func (c *forthCompiler) Complete() (map[string]words.Word, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
 	result := NewCompletionResult()
 	handler := NewCompletionHandler(result)
 	for _, expression := range c.expressionMap {
-		fmt.Printf("Attempting to complete -> \n%s\n", expression.toString())
+		c.logger.Debug("Attempting to complete -> %s", expression.toString())
 		expression.attemptComplete(handler)
 
 		if result.hasError {
-			break
+			return nil, NewCompilationError(result.errorMessage)
 		}
 	}
 
@@ -440,7 +545,7 @@ type InternalIdProvider interface {
 	NextId() string
 }
 
-func NewCompiler(idGenerator InternalIdProvider) ForthCompiler {
+func NewCompiler(idGenerator InternalIdProvider, logger logging.Logger) ForthCompiler {
 	baseId := idGenerator.NextId()
 
 	baseExpression := NewBaseAccumulator(baseId)
@@ -450,6 +555,7 @@ func NewCompiler(idGenerator InternalIdProvider) ForthCompiler {
 	expressionMap[baseId] = baseExpression
 
 	return &forthCompiler{
+		logger:            logger,
 		idGenerator:       idGenerator,
 		currentExpression: baseExpression,
 		expressionIdStack: expressionIdStack,
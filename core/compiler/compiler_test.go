@@ -2,9 +2,13 @@ package compiler_test
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"testing"
 	"tim/forth/core/compiler"
+	"tim/forth/core/logging"
 	"tim/forth/core/support/stacks"
+	"tim/forth/core/words"
 )
 
 var ifS string = "if"
@@ -14,7 +18,7 @@ var thenS string = "then"
 func Test_SupportsFullExpression(t *testing.T) {
 	compiler := compiler.NewCompiler(&testIdProvider{
 		current: 0,
-	})
+	}, logging.NewSilentLogger())
 
 	command := []string{
 		"refib",
@@ -55,7 +59,7 @@ func Test_SupportsFullExpression(t *testing.T) {
 	forthStack := stacks.NewStack()
 	executionStack := stacks.NewStringStack()
 	if refib, found := result["refib"]; found {
-		refib(forthStack, executionStack)
+		refib(words.NewExecutionContext(forthStack, os.Stdout, os.Stderr, logging.NewSilentLogger()), executionStack)
 
 		fmt.Printf("forthStack -> %s <-\n", forthStack.ToString())
 		fmt.Printf("executionStack -> %s <- \n", executionStack.ToString())
@@ -86,7 +90,7 @@ func Test_SupportsNestedIfs(t *testing.T) {
 func Test_missingThen_error(t *testing.T) {
 	compiler := compiler.NewCompiler(&testIdProvider{
 		current: 0,
-	})
+	}, logging.NewSilentLogger())
 
 	command := []string{
 		"blah",
@@ -100,7 +104,7 @@ func Test_missingThen_error(t *testing.T) {
 	for _, word := range command {
 		compiler.PushWord(word)
 	}
-	err, _ := compiler.Complete()
+	_, err := compiler.Complete()
 
 	if err == nil {
 		t.Error("Should have gotten an error when then is missing")
@@ -110,7 +114,7 @@ func Test_missingThen_error(t *testing.T) {
 func Test_missingIf_hasElseThen_error(t *testing.T) {
 	compiler := compiler.NewCompiler(&testIdProvider{
 		current: 0,
-	})
+	}, logging.NewSilentLogger())
 
 	command := []string{
 		elseS,
@@ -121,7 +125,7 @@ func Test_missingIf_hasElseThen_error(t *testing.T) {
 	for _, word := range command {
 		compiler.PushWord(word)
 	}
-	err, _ := compiler.Complete()
+	_, err := compiler.Complete()
 
 	if err == nil {
 		t.Error("Should have gotten an error")
@@ -131,7 +135,7 @@ func Test_missingIf_hasElseThen_error(t *testing.T) {
 func Test_missingIf_hasThen_error(t *testing.T) {
 	compiler := compiler.NewCompiler(&testIdProvider{
 		current: 0,
-	})
+	}, logging.NewSilentLogger())
 
 	command := []string{
 		"1",
@@ -141,7 +145,7 @@ func Test_missingIf_hasThen_error(t *testing.T) {
 	for _, word := range command {
 		compiler.PushWord(word)
 	}
-	err, _ := compiler.Complete()
+	_, err := compiler.Complete()
 
 	if err == nil {
 		t.Error("Should have gotten an error")
@@ -160,3 +164,281 @@ func (idProvider *testIdProvider) NextId() string {
 
 	return id
 }
+
+func compile(t *testing.T, command []string) map[string]words.Word {
+	compiler := compiler.NewCompiler(&testIdProvider{
+		current: 0,
+	}, logging.NewSilentLogger())
+
+	for _, word := range command {
+		compiler.PushWord(word)
+	}
+	result, err := compiler.Complete()
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error on complete, got [%v]", err))
+	}
+
+	return result
+}
+
+// run is a minimal interpreter loop over the compiled words, the native and
+// control words and number literals.
+func run(t *testing.T, compiled map[string]words.Word, word string) *stacks.ForthStack {
+	return runWith(t, compiled, word, stacks.NewStack())
+}
+
+func runWith(t *testing.T, compiled map[string]words.Word, word string, forthStack *stacks.ForthStack) *stacks.ForthStack {
+	ctx := words.NewExecutionContext(forthStack, os.Stdout, os.Stderr, logging.NewSilentLogger())
+	executionStack := stacks.NewStringStack()
+	executionStack.Push(word)
+
+	natives := words.NativeWords()
+	control := words.ControlWords()
+	for {
+		if executionStack.IsEmpty() {
+			return forthStack
+		}
+
+		next := executionStack.Pop()
+
+		var err error
+		if value, parseErr := strconv.ParseInt(next, 10, 64); parseErr == nil {
+			forthStack.Push(stacks.Number{Value: value})
+		} else if fun, found := compiled[next]; found {
+			err = fun(ctx, executionStack)
+		} else if fun, found := control[next]; found {
+			err = fun(ctx, executionStack)
+		} else if fun, found := natives[next]; found {
+			err = fun(ctx)
+		} else {
+			t.Error(fmt.Sprintf("Unknown word [%s]", next))
+			return forthStack
+		}
+
+		if err != nil {
+			t.Error(fmt.Sprintf("Did not expect an error running [%s], got [%v]", next, err))
+			return forthStack
+		}
+	}
+}
+
+func expectStack(t *testing.T, stack *stacks.ForthStack, expected string) {
+	if stack.ToString() != expected {
+		t.Error(fmt.Sprintf("Expected the stack to be %s, instead got %s", expected, stack.ToString()))
+	}
+}
+
+func Test_DoLoop_runsTheBodyForEachIndex(t *testing.T) {
+	result := compile(t, []string{"count", "4", "0", "do", "i", "loop"})
+
+	expectStack(t, run(t, result, "count"), "[3][2][1][0]")
+}
+
+func Test_QuestionDo_skipsTheBodyWhenIndexIsTheLimit(t *testing.T) {
+	result := compile(t, []string{"none", "3", "3", "?do", "i", "loop"})
+
+	expectStack(t, run(t, result, "none"), "")
+}
+
+func Test_PlusLoop_countsDownAcrossTheLimit(t *testing.T) {
+	result := compile(t, []string{"down", "0", "10", "DO", "i", "-3", "+LOOP"})
+
+	expectStack(t, run(t, result, "down"), "[1][4][7][10]")
+}
+
+func Test_NestedDoLoops_exposeBothIndexes(t *testing.T) {
+	result := compile(t, []string{"grid", "2", "0", "do", "2", "0", "do", "j", "i", "loop", "loop"})
+
+	expectStack(t, run(t, result, "grid"), "[1][1][0][1][1][0][0][0]")
+}
+
+func Test_Leave_endsTheLoopFromInsideAnIf(t *testing.T) {
+	result := compile(t, []string{"early", "10", "0", "do", "i", "2", "==", ifS, "drop", "drop", "drop", "leave", elseS, "drop", "drop", "drop", thenS, "i", "loop"})
+
+	expectStack(t, run(t, result, "early"), "[1][0]")
+}
+
+func Test_IfInsideDo_insideIf_compiles(t *testing.T) {
+	result := compile(t, []string{"nested", "0", "0", "==", ifS, "3", "0", "do", "i", "loop", thenS})
+
+	if len(result) != 4 {
+		t.Error(fmt.Sprintf("Expected %d entries in the function results, instead got %d", 4, len(result)))
+	}
+	expectStack(t, run(t, result, "nested"), "[2][1][0][0][0][0]")
+}
+
+func Test_missingLoop_error(t *testing.T) {
+	compiler := compiler.NewCompiler(&testIdProvider{
+		current: 0,
+	}, logging.NewSilentLogger())
+
+	for _, word := range []string{"blah", "3", "0", "do", "i"} {
+		compiler.PushWord(word)
+	}
+	_, err := compiler.Complete()
+
+	if err == nil {
+		t.Error("Should have gotten an error when loop is missing")
+	}
+}
+
+func Test_loopClosingAnIf_error(t *testing.T) {
+	compiler := compiler.NewCompiler(&testIdProvider{
+		current: 0,
+	}, logging.NewSilentLogger())
+
+	for _, word := range []string{"blah", "3", "0", "do", ifS, "i", "loop", thenS} {
+		compiler.PushWord(word)
+	}
+	_, err := compiler.Complete()
+
+	if err == nil {
+		t.Error("Should have gotten an error when loop closes an if")
+	}
+}
+
+func Test_BeginUntil_loopsUntilTheFlagIsTrue(t *testing.T) {
+	result := compile(t, []string{"countdown", "begin", "dup", "1", "flip", "-", "dup", "until"})
+
+	stack := stacks.NewStack()
+	stack.Push(stacks.Number{Value: 3})
+	expectStack(t, runWith(t, result, "countdown", stack), "[0][1][2][3]")
+}
+
+func Test_BeginWhileRepeat_checksBeforeEachPass(t *testing.T) {
+	result := compile(t, []string{"upto", "BEGIN", "dup", "3", ">", "WHILE", "drop", "1", "+", "REPEAT"})
+
+	stack := stacks.NewStack()
+	stack.Push(stacks.Number{Value: 0})
+	expectStack(t, runWith(t, result, "upto", stack), "[3][3][3][2][1][0]")
+}
+
+func Test_BeginInsideIf_insideDo_compiles(t *testing.T) {
+	result := compile(t, []string{"nested", "2", "0", "do", "0", "0", "==", ifS, "drop", "drop", "drop", "i", "begin", "0", "until", thenS, "loop"})
+
+	expectStack(t, run(t, result, "nested"), "[1][0]")
+}
+
+func Test_whileWithoutRepeat_error(t *testing.T) {
+	compiler := compiler.NewCompiler(&testIdProvider{
+		current: 0,
+	}, logging.NewSilentLogger())
+
+	for _, word := range []string{"blah", "begin", "1", "while", "2", "until"} {
+		compiler.PushWord(word)
+	}
+	_, err := compiler.Complete()
+
+	if err == nil {
+		t.Error("Should have gotten an error when until follows while")
+	}
+}
+
+func Test_Does_splitsTheDefinitionInTwo(t *testing.T) {
+	result := compile(t, []string{"counter", "create", "0", ",", "does>", "@"})
+
+	if len(result) != 2 {
+		t.Error(fmt.Sprintf("Expected %d entries in the function results, instead got %d", 2, len(result)))
+	}
+	if _, found := result["id_1:does"]; !found {
+		t.Error("There should have been an 'id_1:does' entry")
+	}
+
+	executionStack := stacks.NewStringStack()
+	result["counter"](words.NewExecutionContext(stacks.NewStack(), os.Stdout, os.Stderr, logging.NewSilentLogger()), executionStack)
+
+	last := executionStack.Items()[len(executionStack.Items())-1]
+	if executionStack.Pop() != "create" || last != compiler.DoesWord+" id_1:does" {
+		t.Error(fmt.Sprintf("Expected the body to end with the DOES> word, instead got %s", executionStack.ToString()))
+	}
+}
+
+func Test_DoesInsideIf_error(t *testing.T) {
+	compiler := compiler.NewCompiler(&testIdProvider{
+		current: 0,
+	}, logging.NewSilentLogger())
+
+	for _, word := range []string{"blah", "0", ifS, "does>", thenS} {
+		compiler.PushWord(word)
+	}
+	_, err := compiler.Complete()
+
+	if err == nil {
+		t.Error("Should have gotten an error when does> is inside an if")
+	}
+}
+
+func Test_SupportsCaseExpression(t *testing.T) {
+	compiler := compiler.NewCompiler(&testIdProvider{
+		current: 0,
+	}, logging.NewSilentLogger())
+
+	command := []string{
+		"describe",
+		"case",
+		"1", "of", "10", "endof",
+		"2", "of", "20", "endof",
+		"dup", "100", "*", "flip",
+		"endcase",
+	}
+
+	for _, word := range command {
+		compiler.PushWord(word)
+	}
+	result, err := compiler.Complete()
+
+	if err != nil {
+		t.Error(fmt.Sprintf("Got an error on complete [%v]", err))
+		return
+	}
+
+	// describe, the case itself, one word per clause and the end of the case
+	if len(result) != 5 {
+		t.Error(fmt.Sprintf("Expected %d entries in the function results, instead got %d", 5, len(result)))
+	}
+
+	for selector, expected := range map[int64]string{1: "[10]", 2: "[20]", 3: "[300]"} {
+		stack := stacks.NewStack()
+		stack.Push(stacks.Number{Value: selector})
+		expectStack(t, runWith(t, result, "describe", stack), expected)
+	}
+}
+
+func Test_CaseInsideIf_withIfInsideOf_compiles(t *testing.T) {
+	result := compile(t, []string{"nested", ifS, "case", "0", "of", "0", ifS, "7", thenS, "endof", "endcase", thenS})
+
+	stack := stacks.NewStack()
+	stack.Push(stacks.Number{Value: 0})
+	stack.Push(stacks.Number{Value: 0})
+	expectStack(t, runWith(t, result, "nested", stack), "[7][0][0]")
+}
+
+func Test_ofOutsideCase_error(t *testing.T) {
+	compiler := compiler.NewCompiler(&testIdProvider{
+		current: 0,
+	}, logging.NewSilentLogger())
+
+	for _, word := range []string{"blah", "1", "of", "2", "endof"} {
+		compiler.PushWord(word)
+	}
+	_, err := compiler.Complete()
+
+	if err == nil {
+		t.Error("Should have gotten an error when of is not inside a case")
+	}
+}
+
+func Test_missingEndOf_error(t *testing.T) {
+	compiler := compiler.NewCompiler(&testIdProvider{
+		current: 0,
+	}, logging.NewSilentLogger())
+
+	for _, word := range []string{"blah", "case", "1", "of", "2", "endcase"} {
+		compiler.PushWord(word)
+	}
+	_, err := compiler.Complete()
+
+	if err == nil {
+		t.Error("Should have gotten an error when endcase closes an open of")
+	}
+}
@@ -0,0 +1,139 @@
+package compiler
+
+import (
+	"fmt"
+	"tim/forth/core/support/stacks"
+	"tim/forth/core/words"
+)
+
+type doAccumulator struct {
+	label      string
+	identifier string
+	body       ExpressionQueue
+	utils      consumerUtils
+
+	// skipWhenEqual is set for ?DO, which runs the body no times at all when the
+	// index starts at the limit.
+	skipWhenEqual bool
+	plusLoop      bool
+	isComplete    bool
+}
+
+func (acc *doAccumulator) push(word string, resultHandler ExpressionPushHandler) {
+	if acc.utils.isLoop(word) || acc.utils.isPlusLoop(word) {
+		acc.plusLoop = acc.utils.isPlusLoop(word)
+		acc.isComplete = true
+		resultHandler.onComplete()
+		return
+	}
+
+	if acc.utils.isControlWord(word) {
+		resultHandler.onRejected()
+		return
+	}
+
+	acc.body.Enqueue(word)
+}
+
+func pushBody(executionStack stacks.StringStack, step string, body []string) {
+	executionStack.Push(step)
+	for _, v := range body {
+		executionStack.Push(v)
+	}
+}
+
+// stepName is the word run after each pass through the body, it moves the index
+// on and either runs the body again or ends the loop.
+func (acc *doAccumulator) stepName() string {
+	return fmt.Sprintf("%s:loop", acc.label)
+}
+
+func (acc *doAccumulator) start(body []string) words.Word {
+	return func(ctx *words.ExecutionContext, executionStack stacks.StringStack) error {
+		if ctx.Stack.Size() < 2 {
+			return words.NewUnderflowError()
+		}
+
+		index := ctx.Stack.Pop().ValueOf()
+		limit := ctx.Stack.Pop().ValueOf()
+
+		if acc.skipWhenEqual && index == limit {
+			return nil
+		}
+
+		ctx.Loops.Push(&words.LoopFrame{
+			Id:    acc.label,
+			Index: index,
+			Limit: limit,
+			Base:  executionStack.Size(),
+		})
+		pushBody(executionStack, acc.stepName(), body)
+
+		return nil
+	}
+}
+
+func (acc *doAccumulator) step(body []string) words.Word {
+	return func(ctx *words.ExecutionContext, executionStack stacks.StringStack) error {
+		frame, err := ctx.Loops.Peek(0)
+		if err != nil {
+			return err
+		}
+		if frame.Id != acc.label {
+			return words.NewControlFlowError("the innermost loop is not the one being stepped, was UNLOOP used without EXIT?")
+		}
+
+		increment := int64(1)
+		if acc.plusLoop {
+			if ctx.Stack.IsEmpty() {
+				return words.NewUnderflowError()
+			}
+			increment = ctx.Stack.Pop().ValueOf()
+		}
+
+		// The loop ends when the index crosses the boundary between limit - 1 and
+		// limit, in either direction.
+		before := frame.Index - frame.Limit
+		after := before + increment
+		if (before < 0) != (after < 0) {
+			ctx.Loops.Pop()
+			return nil
+		}
+
+		frame.Index = frame.Index + increment
+		pushBody(executionStack, acc.stepName(), body)
+
+		return nil
+	}
+}
+
+func (acc *doAccumulator) attemptComplete(handler CompletionHandler) {
+	if !acc.isComplete {
+		handler.onError("Can not complete a do loop without a loop")
+		return
+	}
+
+	body := reverse(toSlice(acc.body))
+
+	handler.onNativeComplete(acc.label, acc.start(body))
+	handler.onNativeComplete(acc.stepName(), acc.step(body))
+}
+func (acc *doAccumulator) toString() string {
+	return fmt.Sprintf("[%s] -> [DO] %s \nisComplete -> %t\n", acc.name(), acc.body.ToString(), acc.isComplete)
+}
+func (acc *doAccumulator) name() string {
+	return acc.label
+}
+func (acc *doAccumulator) id() string {
+	return acc.identifier
+}
+
+func NewDoExpressionAccumulator(id string, skipWhenEqual bool) ExpressionAccumulator {
+	return &doAccumulator{
+		identifier:    id,
+		label:         id,
+		body:          NewExpressionQueue(),
+		utils:         consumerUtils{},
+		skipWhenEqual: skipWhenEqual,
+	}
+}
@@ -0,0 +1,37 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DoesWord takes the place of DOES> in a compiled definition, it is followed by
+// the name of the word holding everything after DOES>. When it runs it gives the
+// most recently CREATEd word that behaviour.
+const DoesWord = "(does>)"
+
+func (util consumerUtils) isDoes(s string) bool {
+	return strings.ToLower(s) == "does>"
+}
+
+// startDoes ends the current definition with DoesWord and starts collecting the
+// rest of the words into a new definition named after the DOES> part.
+func (c *forthCompiler) startDoes() error {
+	if !c.expressionIdStack.IsEmpty() {
+		c.err = NewCompilationError(fmt.Sprintf("[does>] can not be used inside %s", c.currentExpression.toString()))
+		return c.err
+	}
+
+	id := c.idGenerator.NextId()
+	label := fmt.Sprintf("%s:does", id)
+
+	c.currentExpression.push(fmt.Sprintf("%s %s", DoesWord, label), NewResultHandler(c, DoesWord))
+
+	exp := NewBaseAccumulator(id)
+	exp.push(label, NewResultHandler(c, label))
+
+	c.expressionMap[exp.id()] = exp
+	c.currentExpression = exp
+
+	return c.err
+}
@@ -0,0 +1,206 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"tim/forth/core/compiler"
+	"tim/forth/core/support/stacks"
+	"tim/forth/core/words"
+)
+
+func (i *ForthInterpreter) setCompiling(compiling bool) error {
+	return i.context.Memory.Store(i.stateAddress, i.context.Flag(compiling))
+}
+
+func (i *ForthInterpreter) isDefining() bool {
+	return !i.newWordAccumulator.label.isEmpty()
+}
+
+func (i *ForthInterpreter) requireDefinition(word string) error {
+	if !i.isDefining() {
+		return words.NewInvalidArgument(fmt.Sprintf("[%s] can only be used while defining a word", word))
+	}
+
+	return nil
+}
+
+// compilingWords are the words that start, end and extend definitions.
+func compilingWords(i *ForthInterpreter) map[string]*dictionaryEntry {
+	result := make(map[string]*dictionaryEntry)
+
+	result[":"] = newInterpreterEntry(i, func(i *ForthInterpreter) error {
+		return startRecording(i, ":")
+	})
+	result[":noname"] = newInterpreterEntry(i, func(i *ForthInterpreter) error {
+		return startAnonymousRecording(i, ":noname")
+	})
+	result[";"] = newImmediateEntry(i, func(i *ForthInterpreter) error {
+		err := i.requireDefinition(";")
+		if err != nil {
+			return err
+		}
+
+		return endRecording(i, ";")
+	})
+	result["["] = newImmediateEntry(i, func(i *ForthInterpreter) error {
+		err := i.requireDefinition("[")
+		if err != nil {
+			return err
+		}
+
+		i.handler = executeCommand
+		return i.setCompiling(false)
+	})
+	result["]"] = newInterpreterEntry(i, func(i *ForthInterpreter) error {
+		err := i.requireDefinition("]")
+		if err != nil {
+			return err
+		}
+
+		i.handler = record
+		return i.setCompiling(true)
+	})
+	result["literal"] = newImmediateEntry(i, func(i *ForthInterpreter) error {
+		err := i.requireDefinition("literal")
+		if err != nil {
+			return err
+		}
+
+		value, err := i.popValue()
+		if err != nil {
+			return err
+		}
+
+		i.newWordAccumulator.insert(strconv.FormatInt(value, 10))
+		return nil
+	})
+	result["immediate"] = newInterpreterEntry(i, markImmediate)
+	result["state"] = newInterpreterEntry(i, func(i *ForthInterpreter) error {
+		i.stack.Push(stacks.Number{Value: i.stateAddress})
+		return nil
+	})
+
+	result["exit"] = newDictionaryEntry(NativeWord, i.exit)
+
+	recurse := newImmediateEntry(i, func(i *ForthInterpreter) error {
+		return compileRecurse(i, "")
+	})
+	recurse.compile = compileRecurse
+	result["recurse"] = recurse
+
+	postpone := newOperandEntry("postpone", appendToDefinition)
+	postpone.compile = compilePostpone
+	result["postpone"] = postpone
+
+	return result
+}
+
+func newInterpreterEntry(i *ForthInterpreter, fun func(*ForthInterpreter) error) *dictionaryEntry {
+	return newDictionaryEntry(NativeWord, wrapInterpreterWord(i, fun))
+}
+
+func newImmediateEntry(i *ForthInterpreter, fun func(*ForthInterpreter) error) *dictionaryEntry {
+	entry := newInterpreterEntry(i, fun)
+	entry.immediate = true
+
+	return entry
+}
+
+// markImmediate is IMMEDIATE, it makes the last definition run as soon as it
+// is met while compiling.
+func markImmediate(i *ForthInterpreter) error {
+	defined, found := i.words[i.lastDefined]
+	if i.lastDefined == "" || !found {
+		return words.NewInvalidArgument("[immediate] needs a word to have been defined")
+	}
+
+	entry := *defined
+	entry.immediate = true
+	if entry.source != "" {
+		entry.source = entry.source + " immediate"
+	}
+	i.words[i.lastDefined] = &entry
+
+	return nil
+}
+
+// compilePostpone is POSTPONE met while compiling. An immediate word is added to
+// the definition so it runs when the definition does, any other word is left
+// for that run to add to whatever definition is being compiled at the time.
+func compilePostpone(i *ForthInterpreter, name string) error {
+	if name == "" {
+		return words.NewInvalidArgument("[postpone] expects a name to follow it")
+	}
+
+	// The compiler's own words, like IF, only mean something once the whole
+	// definition is compiled so they are always left to be added later.
+	if compiler.IsCompilerWord(name) {
+		i.newWordAccumulator.insert(joinOperand("postpone", name))
+		return nil
+	}
+
+	entry, found := i.lookup(name)
+	if !found {
+		return &UndefinedWordError{
+			Word:  name,
+			Stack: i.stack.Items(),
+		}
+	}
+
+	if entry.immediate {
+		i.newWordAccumulator.insert(name)
+	} else {
+		i.newWordAccumulator.insert(joinOperand("postpone", name))
+	}
+
+	return nil
+}
+
+func appendToDefinition(i *ForthInterpreter, name string) error {
+	err := i.requireDefinition("postpone")
+	if err != nil {
+		return err
+	}
+
+	i.newWordAccumulator.insert(name)
+	return nil
+}
+
+// compileRecurse is RECURSE, it adds a hidden name for the word being defined
+// so it still calls itself if an older word has the same name.
+func compileRecurse(i *ForthInterpreter, _ string) error {
+	err := i.requireDefinition("recurse")
+	if err != nil {
+		return err
+	}
+
+	accumulator := i.newWordAccumulator
+	if accumulator.self == "" {
+		accumulator.self = fmt.Sprintf("%s:self%d", accumulator.label.value(), i.definitionCount+1)
+	}
+
+	accumulator.insert(accumulator.self)
+	return nil
+}
+
+// exit is EXIT, it drops the rest of the running definition from the execution
+// stack along with any loops it started.
+func (i *ForthInterpreter) exit(executionContext *words.ExecutionContext, executionStack stacks.StringStack) error {
+	running, found := i.frames.innermost()
+	if !found {
+		return words.NewControlFlowError("[exit] can only be used inside a definition")
+	}
+
+	loops := executionContext.Loops
+	for {
+		loop, err := loops.Peek(0)
+		if err != nil || loop.Base < running.base {
+			break
+		}
+
+		loops.Pop()
+	}
+
+	words.Unwind(executionStack, running.base)
+	return nil
+}
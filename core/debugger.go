@@ -0,0 +1,113 @@
+package core
+
+import (
+	"fmt"
+	"tim/forth/core/support/stacks"
+)
+
+type DebugCommand int
+
+const (
+	// DebugStep pauses again at the very next word.
+	DebugStep DebugCommand = iota
+	// DebugStepOver runs the paused word to completion before pausing again.
+	DebugStepOver
+	// DebugContinue runs until the next breakpoint.
+	DebugContinue
+	// DebugAbort stops the run with a DebugAbortedError.
+	DebugAbort
+)
+
+type DebugPause struct {
+	Word           string
+	Kind           WordKind
+	Breakpoint     bool
+	Stack          []stacks.ForthItem
+	ExecutionStack []string
+}
+
+// DebugController is asked what to do each time execution pauses, either on a
+// breakpoint or because the previous pause asked to step.
+type DebugController interface {
+	Paused(pause DebugPause) DebugCommand
+}
+
+type DebugAbortedError struct {
+	Word string
+}
+
+func (e *DebugAbortedError) Error() string {
+	return fmt.Sprintf("Aborted from the debugger at word [%s]", e.Word)
+}
+
+type debugger struct {
+	controller  DebugController
+	breakpoints map[string]bool
+	stepping    bool
+	overDepth   int
+}
+
+func (d *debugger) shouldPause(word string, executionStack stacks.StringStack) bool {
+	if d.breakpoints[word] {
+		return true
+	}
+
+	if d.stepping {
+		return true
+	}
+
+	return d.overDepth > 0 && executionStack.Size() < d.overDepth
+}
+
+func (d *debugger) beforeWord(i *ForthInterpreter, word string, kind WordKind, executionStack stacks.StringStack) error {
+	if d.controller == nil || !d.shouldPause(word, executionStack) {
+		return nil
+	}
+
+	command := d.controller.Paused(DebugPause{
+		Word:           word,
+		Kind:           kind,
+		Breakpoint:     d.breakpoints[word],
+		Stack:          i.stack.Items(),
+		ExecutionStack: executionStack.Items(),
+	})
+
+	d.reset()
+	switch command {
+	case DebugStep:
+		d.stepping = true
+	case DebugStepOver:
+		d.overDepth = executionStack.Size()
+	case DebugAbort:
+		return &DebugAbortedError{Word: word}
+	}
+
+	return nil
+}
+
+func (d *debugger) reset() {
+	d.stepping = false
+	d.overDepth = 0
+}
+
+func newDebugger() *debugger {
+	return &debugger{
+		breakpoints: make(map[string]bool),
+	}
+}
+
+// SetDebugController enables breakpoints, passing nil disables them.
+func (i *ForthInterpreter) SetDebugController(controller DebugController) {
+	i.debugger.controller = controller
+}
+
+func setBreakpoint(i *ForthInterpreter, word string) error {
+	i.debugger.breakpoints[word] = true
+
+	return nil
+}
+func clearBreakpoint(i *ForthInterpreter, word string) error {
+	delete(i.debugger.breakpoints, word)
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"tim/forth/core/support/stacks"
+	"tim/forth/core/words"
+)
+
+type WordKind int
+
+const (
+	LiteralWord WordKind = iota
+	NativeWord
+	UserWord
+)
+
+func (k WordKind) ToString() string {
+	switch k {
+	case LiteralWord:
+		return "literal"
+	case NativeWord:
+		return "native"
+	case UserWord:
+		return "user"
+	}
+
+	return "unknown"
+}
+
+type dictionaryEntry struct {
+	kind WordKind
+	run  words.Word
+
+	// source is the text that defined a user word, it is empty for native and
+	// predefined words as well as the compiler's internal expressions.
+	source string
+	order  int
+	// base is the BASE source was written in, 0 meaning decimal.
+	base int64
+
+	// operand is set for words such as TO that take the following word as part
+	// of themselves, see joinOperand.
+	operand func(*ForthInterpreter, string) error
+	// immediate words run while a definition is being compiled rather than
+	// becoming part of it, compile replaces that with behaviour of its own.
+	immediate bool
+	compile   func(*ForthInterpreter, string) error
+
+	// dataField is the address of the cell holding a VALUE, or the first cell
+	// after a CREATEd word.
+	dataField int64
+	isValue   bool
+
+	// image builds the source saved for the word from its current state, when
+	// it is nil the source is saved as it is.
+	image func(*ForthInterpreter, string) (string, error)
+}
+
+func newDictionaryEntry(kind WordKind, run words.Word) *dictionaryEntry {
+	return &dictionaryEntry{
+		kind: kind,
+		run:  run,
+	}
+}
+
+// isDefinition is true for words a user defined, as opposed to native words and
+// the compiler's internal expressions.
+func (entry *dictionaryEntry) isDefinition() bool {
+	return entry.order > 0
+}
+
+func (i *ForthInterpreter) define(name string, source string, run words.Word) {
+	i.definitionCount = i.definitionCount + 1
+
+	entry := newDictionaryEntry(UserWord, run)
+	entry.source = source
+	entry.order = i.definitionCount
+
+	i.words[name] = entry
+	i.lastDefined = name
+}
+
+// joinOperand makes a word and the operand that follows it into a single token,
+// so that `TO x` stays together when it is recorded into a definition. Tokens
+// never contain a space, which keeps the joined form unambiguous.
+func joinOperand(word string, operand string) string {
+	return word + " " + operand
+}
+
+func splitOperand(command string) (string, string, bool) {
+	parts := strings.SplitN(command, " ", 2)
+	if len(parts) < 2 {
+		return command, "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+func (i *ForthInterpreter) takesOperand(word string) bool {
+	entry, found := i.lookup(word)
+	return found && entry.operand != nil
+}
+
+func (entry *dictionaryEntry) withOperand(i *ForthInterpreter, name string, operand string) words.Word {
+	return func(executionContext *words.ExecutionContext, executionStack stacks.StringStack) error {
+		if entry.operand == nil {
+			return words.NewInvalidArgument(fmt.Sprintf("[%s] does not take an operand", name))
+		}
+
+		return entry.operand(i, operand)
+	}
+}
+
+// alias gives an existing word a hidden second name that keeps referring to it
+// after the original name is defined again. Being hidden it has no source of
+// its own for images to save.
+func (i *ForthInterpreter) alias(hidden string, name string) {
+	entry := *i.words[name]
+	entry.source = ""
+
+	i.words[hidden] = &entry
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"fmt"
+	"tim/forth/core/support/stacks"
+)
+
+func stackString(stack []stacks.ForthItem) string {
+	result := ""
+	for _, item := range stack {
+		result = result + fmt.Sprintf("[%s]", item.ToString())
+	}
+
+	return result
+}
+
+// UndefinedWordError is returned when a word is neither a number nor in the dictionary.
+type UndefinedWordError struct {
+	Word  string
+	Stack []stacks.ForthItem
+}
+
+func (e *UndefinedWordError) Error() string {
+	return fmt.Sprintf("Word -> [%s] is not defined, stack -> %s", e.Word, stackString(e.Stack))
+}
+
+// WordError wraps a failure raised while running a word, such as words.UnderflowError
+// or words.InvalidArgument, which remain reachable through errors.As.
+type WordError struct {
+	Word  string
+	Stack []stacks.ForthItem
+	Err   error
+}
+
+func (e *WordError) Error() string {
+	return fmt.Sprintf("Word -> [%s] failed: %s, stack -> %s", e.Word, e.Err.Error(), stackString(e.Stack))
+}
+func (e *WordError) Unwrap() error {
+	return e.Err
+}
+
+// CompileError is returned when a ':' definition can not be compiled.
+type CompileError struct {
+	Word  string
+	Stack []stacks.ForthItem
+	Err   error
+}
+
+func (e *CompileError) Error() string {
+	return fmt.Sprintf("Failed to compile [%s]: %s", e.Word, e.Err.Error())
+}
+func (e *CompileError) Unwrap() error {
+	return e.Err
+}
+
+// SourceError records where in the evaluated source a failing word was found.
+type SourceError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *SourceError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Err.Error())
+}
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// LimitExceededError is returned when a run goes beyond one of the configured Limits.
+type LimitExceededError struct {
+	Limit string
+	Max   int
+	Word  string
+	Stack []stacks.ForthItem
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("Exceeded the %s limit of [%d] at word [%s]", e.Limit, e.Max, e.Word)
+}
+func NewLimitExceededError(limit string, max int, word string, stack *stacks.ForthStack) error {
+	return &LimitExceededError{
+		Limit: limit,
+		Max:   max,
+		Word:  word,
+		Stack: stack.Items(),
+	}
+}
+
+// UnbalancedReturnStackError is returned when a definition ends with more or
+// fewer items on the return stack than it started with.
+type UnbalancedReturnStackError struct {
+	Word     string
+	Expected int
+	Actual   int
+}
+
+func (e *UnbalancedReturnStackError) Error() string {
+	return fmt.Sprintf("Word -> [%s] left the return stack unbalanced, expected a depth of [%d] but found [%d]", e.Word, e.Expected, e.Actual)
+}
+
+// UnsavableWordError is returned when saving an image would lose what a word does.
+type UnsavableWordError struct {
+	Word   string
+	Reason string
+}
+
+func (e *UnsavableWordError) Error() string {
+	return fmt.Sprintf("Word -> [%s] can not be saved in an image: %s", e.Word, e.Reason)
+}
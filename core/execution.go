@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"tim/forth/core/support/stacks"
+)
+
+type RollbackMode int
+
+const (
+	// NoRollback leaves the stack as it was when the failure happened.
+	NoRollback RollbackMode = iota
+	// RollbackWord restores the stack to how it was before the failing word ran.
+	RollbackWord
+	// RollbackLine restores the stack to how it was before the failing call to
+	// Execute or Evaluate, usually a single line in the REPL.
+	RollbackLine
+)
+
+// Limits bounds a single call to ExecuteContext or EvaluateContext, a zero value
+// leaves that dimension unlimited. Steps are words run, as well as the pieces of
+// work in words such as SPACES that can otherwise run for a long time.
+type Limits struct {
+	MaxSteps          int
+	MaxExecutionDepth int
+	MaxStackDepth     int
+}
+
+type execution struct {
+	ctx    context.Context
+	limits Limits
+	steps  int
+}
+
+// count uses up one step of the budget, failing once the context is done.
+func (e *execution) count(word string, stack *stacks.ForthStack) error {
+	err := e.ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	e.steps = e.steps + 1
+	if e.limits.MaxSteps > 0 && e.steps > e.limits.MaxSteps {
+		return NewLimitExceededError("steps", e.limits.MaxSteps, word, stack)
+	}
+
+	return nil
+}
+
+func (e *execution) step(word string, executionStack stacks.StringStack, stack *stacks.ForthStack) error {
+	err := e.count(word, stack)
+	if err != nil {
+		return err
+	}
+
+	if e.limits.MaxExecutionDepth > 0 && executionStack.Size() > e.limits.MaxExecutionDepth {
+		return NewLimitExceededError("execution stack depth", e.limits.MaxExecutionDepth, word, stack)
+	}
+
+	return nil
+}
+
+func (e *execution) checkStack(word string, stack *stacks.ForthStack) error {
+	if e.limits.MaxStackDepth > 0 && stack.Size() > e.limits.MaxStackDepth {
+		return NewLimitExceededError("data stack depth", e.limits.MaxStackDepth, word, stack)
+	}
+
+	return nil
+}
+
+func newExecution(ctx context.Context, limits Limits) *execution {
+	return &execution{
+		ctx:    ctx,
+		limits: limits,
+		steps:  0,
+	}
+}
+
+// withExecution runs fun under a fresh budget, nested calls share the budget of
+// the outermost call. Nothing runs when the interpreter could not be set up.
+func (i *ForthInterpreter) withExecution(ctx context.Context, fun func() error) error {
+	if i.setupErr != nil {
+		return i.setupErr
+	}
+
+	if i.execution != nil {
+		return fun()
+	}
+
+	i.execution = newExecution(ctx, i.limits)
+	defer func() {
+		i.execution = nil
+		i.debugger.reset()
+	}()
+
+	// Loops and the return stack can only be left running by a failure part way through them.
+	i.context.Loops.Truncate(0)
+	i.context.Return = stacks.NewStack()
+
+	before := i.stack.Snapshot()
+	err := fun()
+	if err != nil && i.rollback == RollbackLine {
+		i.stack.Restore(before)
+	}
+
+	return err
+}
@@ -0,0 +1,56 @@
+package core
+
+// frame is a user definition that is running, it ends once the execution stack
+// is back down to the size it was before the definition pushed its body.
+type frame struct {
+	word        string
+	base        int
+	returnDepth int
+}
+
+type frames struct {
+	running []frame
+}
+
+func (f *frames) open(word string, base int, returnDepth int) {
+	f.running = append(f.running, frame{
+		word:        word,
+		base:        base,
+		returnDepth: returnDepth,
+	})
+}
+
+// close ends every definition that has nothing left to run, checking that each
+// left the return stack as deep as it found it.
+func (f *frames) close(executionDepth int, returnDepth int) error {
+	for len(f.running) > 0 {
+		last := f.running[len(f.running)-1]
+		if last.base < executionDepth {
+			return nil
+		}
+
+		f.running = f.running[:len(f.running)-1]
+		if last.returnDepth != returnDepth {
+			return &UnbalancedReturnStackError{
+				Word:     last.word,
+				Expected: last.returnDepth,
+				Actual:   returnDepth,
+			}
+		}
+	}
+
+	return nil
+}
+
+// innermost is the definition that is running right now.
+func (f *frames) innermost() (frame, bool) {
+	if len(f.running) == 0 {
+		return frame{}, false
+	}
+
+	return f.running[len(f.running)-1], true
+}
+
+func newFrames() *frames {
+	return &frames{}
+}
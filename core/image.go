@@ -0,0 +1,194 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"tim/forth/core/support/stacks"
+)
+
+type imageWord struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	// Base is the base the source was written in, it is left out for decimal.
+	Base int64 `json:"base,omitempty"`
+}
+
+// image is the saved form of an interpreter, user words are kept as the source
+// that defined them so loading an image is just evaluating that source again.
+type image struct {
+	Words []imageWord `json:"words"`
+	// Stack is nil when the stack was not saved, an empty saved stack is still
+	// written so loading it empties the stack.
+	Stack *[]int64 `json:"stack,omitempty"`
+	// Tokens names the word behind each execution token in Stack, by index.
+	Tokens map[int]string `json:"tokens,omitempty"`
+}
+
+func (i *ForthInterpreter) userWords() ([]imageWord, error) {
+	entries := []*dictionaryEntry{}
+	names := make(map[*dictionaryEntry]string)
+	for name, entry := range i.words {
+		if entry.source != "" {
+			entries = append(entries, entry)
+			names[entry] = name
+		}
+	}
+
+	sort.Slice(entries, func(a int, b int) bool {
+		return entries[a].order < entries[b].order
+	})
+
+	result := []imageWord{}
+	for _, entry := range entries {
+		source := entry.source
+		if entry.image != nil {
+			var err error
+			source, err = entry.image(i, names[entry])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		word := imageWord{
+			Name:   names[entry],
+			Source: source,
+		}
+		if entry.base != 0 && entry.base != 10 {
+			word.Base = entry.base
+		}
+
+		result = append(result, word)
+	}
+
+	return result, nil
+}
+
+// SaveImage writes every user defined word, and the data stack when includeStack
+// is set, to w. Native and predefined words are left out as every interpreter
+// already has them. Variables and values are saved with what they hold now and
+// execution tokens by the name of their word. Words made by CREATE, and tokens
+// whose word can not be named, return an UnsavableWordError.
+func (i *ForthInterpreter) SaveImage(w io.Writer, includeStack bool) error {
+	userWords, err := i.userWords()
+	if err != nil {
+		return err
+	}
+
+	saved := image{
+		Words: userWords,
+	}
+
+	if includeStack {
+		items := i.stack.Items()
+		stack := []int64{}
+		for index := len(items) - 1; index >= 0; index-- {
+			if token, isToken := items[index].(stacks.ExecutionToken); isToken {
+				name, err := i.savedTokenName(token)
+				if err != nil {
+					return err
+				}
+
+				if saved.Tokens == nil {
+					saved.Tokens = make(map[int]string)
+				}
+				saved.Tokens[len(stack)] = name
+			}
+
+			stack = append(stack, items[index].ValueOf())
+		}
+		saved.Stack = &stack
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(saved)
+}
+
+// LoadImage defines the words saved in an image and, when one was saved,
+// replaces the data stack with the saved one.
+func (i *ForthInterpreter) LoadImage(r io.Reader) (err error) {
+	loaded := image{}
+	err = json.NewDecoder(r).Decode(&loaded)
+	if err != nil {
+		return err
+	}
+
+	// Saved sources are read in the base they were written in, decimal unless
+	// the image says otherwise, whatever BASE is now.
+	memory := i.context.Memory
+	base, err := memory.Fetch(i.context.BaseAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		restoreErr := memory.Store(i.context.BaseAddress, base)
+		if err == nil {
+			err = restoreErr
+		}
+	}()
+
+	for _, word := range loaded.Words {
+		sourceBase := word.Base
+		if sourceBase == 0 {
+			sourceBase = 10
+		}
+		err = memory.Store(i.context.BaseAddress, sourceBase)
+		if err != nil {
+			return err
+		}
+
+		err = i.Evaluate(word.Source)
+		if err != nil {
+			return fmt.Errorf("loading [%s]: %w", word.Name, err)
+		}
+	}
+
+	if loaded.Stack != nil {
+		i.stack.Restore(stacks.NewStack().Snapshot())
+		for index, value := range *loaded.Stack {
+			name, isToken := loaded.Tokens[index]
+			if !isToken {
+				i.stack.Push(stacks.Number{Value: value})
+				continue
+			}
+
+			err = i.pushToken(name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func saveImageFile(includeStack bool) func(*ForthInterpreter, string) error {
+	return func(i *ForthInterpreter, path string) error {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+
+		err = i.SaveImage(file, includeStack)
+		if err != nil {
+			file.Close()
+			return err
+		}
+
+		return file.Close()
+	}
+}
+
+func loadImageFile(i *ForthInterpreter, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return i.LoadImage(file)
+}
@@ -1,10 +1,17 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
+	"strings"
 	"tim/forth/core/compiler"
+	"tim/forth/core/logging"
+	"tim/forth/core/support/memory"
 	"tim/forth/core/support/stacks"
+	"tim/forth/core/tokenizer"
 	"tim/forth/core/words"
 
 	"github.com/google/uuid"
@@ -12,47 +19,170 @@ import (
 
 type ForthInterpreter struct {
 	stack              *stacks.ForthStack
+	context            *words.ExecutionContext
 	newWordAccumulator *newWordAccumulator
-	words              map[string]func(*stacks.ForthStack, stacks.StringStack) error
-	handler            func(*ForthInterpreter, string)
+	words              map[string]*dictionaryEntry
+	handler            func(*ForthInterpreter, string) error
+	limits             Limits
+	execution          *execution
+	tracer             Tracer
+	tracing            bool
+	debugger           *debugger
+	input              tokenizer.Tokenizer
+	definitionCount    int
+	rollback           RollbackMode
+
+	tokens *executionTokens
+	// frames are the definitions running in the innermost processCommand.
+	frames *frames
+
+	// stateAddress is the cell behind STATE, it is true while compiling.
+	stateAddress int64
+	// setupErr is why memory could not be set up, every run returns it.
+	setupErr error
+	// lastDefined is the most recent definition, the one IMMEDIATE changes.
+	lastDefined string
+
+	// lastCreated is the most recent word made by CREATE, the one DOES> changes.
+	lastCreated string
 }
 
-func processCommand(i *ForthInterpreter, executionStack stacks.StringStack) {
+func processCommand(i *ForthInterpreter, executionStack stacks.StringStack) error {
+	running := newFrames()
+	// Only the word that started the run was typed in, everything after it comes
+	// from definitions whose literals were already made decimal by record.
+	fromInput := true
+
+	previousFrames := i.frames
+	i.frames = running
+	defer func() {
+		i.frames = previousFrames
+	}()
+
 	for {
+		err := running.close(executionStack.Size(), i.context.Return.Size())
+		if err != nil {
+			return err
+		}
+
 		if executionStack.IsEmpty() {
-			break
+			return nil
 		}
 
 		command := executionStack.Pop()
 
-		num, err := strconv.ParseInt(command, 10, 64)
-		if err == nil {
+		err = i.execution.step(command, executionStack, i.stack)
+		if err != nil {
+			return err
+		}
+
+		name, operand, hasOperand := splitOperand(command)
+		entry, found := i.lookup(name)
+		if !found {
+			num, isNumber, err := i.parseLiteral(command, fromInput)
+			if err != nil {
+				return &WordError{
+					Word:  command,
+					Stack: i.stack.Items(),
+					Err:   err,
+				}
+			}
+			if !isNumber {
+				return &UndefinedWordError{
+					Word:  command,
+					Stack: i.stack.Items(),
+				}
+			}
+			fromInput = false
+
+			err = i.debugger.beforeWord(i, command, LiteralWord, executionStack)
+			if err != nil {
+				return err
+			}
+
+			i.trace(command, LiteralWord, executionStack, traceBefore)
 			i.stack.Push(stacks.Number{Value: num})
+			err = i.execution.checkStack(command, i.stack)
+			if err != nil {
+				return err
+			}
+			i.trace(command, LiteralWord, executionStack, traceAfter)
 			continue
 		}
+		fromInput = false
 
-		fun, found := i.words[command]
-		if !found {
-			fmt.Printf("Word -> [%s] is not defined (the stack should probably be dumped in this case)\n", command)
-			fmt.Println("Available commands are:")
-			for key := range i.words {
-				fmt.Println(key)
+		run := entry.run
+		if hasOperand {
+			run = entry.withOperand(i, name, operand)
+		}
+
+		err = i.debugger.beforeWord(i, command, entry.kind, executionStack)
+		if err != nil {
+			return err
+		}
+
+		i.trace(command, entry.kind, executionStack, traceBefore)
+		before := i.stack.Snapshot()
+		base := executionStack.Size()
+		returnDepth := i.context.Return.Size()
+		err = run(i.context, executionStack)
+		if err != nil {
+			if i.rollback != NoRollback {
+				i.stack.Restore(before)
 			}
-			break
+
+			return &WordError{
+				Word:  command,
+				Stack: i.stack.Items(),
+				Err:   err,
+			}
+		}
+
+		if entry.isDefinition() {
+			running.open(command, base, returnDepth)
 		}
 
-		err = fun(i.stack, executionStack)
+		err = i.execution.checkStack(command, i.stack)
+		if err != nil {
+			return err
+		}
+		i.trace(command, entry.kind, executionStack, traceAfter)
+	}
+}
+
+// parseLiteral reads a number, in the current BASE when it was typed in and in
+// decimal when it is part of a definition.
+func (i *ForthInterpreter) parseLiteral(command string, fromInput bool) (int64, bool, error) {
+	base := 10
+	if fromInput {
+		radix, err := i.context.Radix()
 		if err != nil {
-			fmt.Println("Error: ", err)
+			return 0, false, err
 		}
+		base = radix
+	}
+
+	num, isNumber := words.ParseNumber(command, base)
+	return num, isNumber, nil
+}
+
+// lookup finds a word as it was written, falling back to its lower case form
+// so that IF, If and if are the same word.
+func (i *ForthInterpreter) lookup(word string) (*dictionaryEntry, bool) {
+	entry, found := i.words[word]
+	if found {
+		return entry, true
 	}
+
+	entry, found = i.words[strings.ToLower(word)]
+	return entry, found
 }
 
-func executeCommand(i *ForthInterpreter, s string) {
+func executeCommand(i *ForthInterpreter, s string) error {
 	executionStack := stacks.NewStringStack()
 	executionStack.Push(s)
 
-	processCommand(i, executionStack)
+	return processCommand(i, executionStack)
 }
 
 type wordEntry struct {
@@ -78,46 +208,193 @@ func (idProvider *uuidProvider) NextId() string {
 	return id.String()
 }
 
-func endRecording(i *ForthInterpreter, _ string) {
-	compiler := compiler.NewCompiler(&uuidProvider{})
+// definitionSource is the definition as it was written when all of it was read
+// from one source in one base, otherwise it is rebuilt in decimal from the words
+// that were compiled.
+func (i *ForthInterpreter) definitionSource(accumulator *newWordAccumulator) (string, int64) {
+	base, err := i.context.Memory.Fetch(i.context.BaseAddress)
+	if err == nil && accumulator.input != nil && accumulator.input == i.input && base == accumulator.base {
+		return ":" + i.input.Text(accumulator.start, i.input.Offset()), base
+	}
+
+	return accumulator.source(), 10
+}
+
+func endRecording(i *ForthInterpreter, _ string) error {
+	compiler := compiler.NewCompiler(&uuidProvider{}, i.context.Logger)
 
 	accumulator := i.newWordAccumulator
 	i.newWordAccumulator.label.value()
 	compiler.PushWord(accumulator.label.value())
 
-	for _, w := range accumulator.body[0:accumulator.wordCount] {
+	for _, w := range accumulator.body {
 		compiler.PushWord(w)
 	}
 
 	words, err := compiler.Complete()
+
+	i.handler = executeCommand
+	i.newWordAccumulator = NewWordAccumulator()
+
+	stateErr := i.setCompiling(false)
+	if stateErr != nil {
+		return stateErr
+	}
+
 	if err != nil {
-		fmt.Println("Failed to process some stuff :(")
-	} else {
-		for label, body := range words {
-			i.words[label] = body
+		return &CompileError{
+			Word:  accumulator.label.value(),
+			Stack: i.stack.Items(),
+			Err:   err,
 		}
 	}
 
-	i.handler = executeCommand
-	i.newWordAccumulator = NewWordAccumulator()
+	name := accumulator.label.value()
+	source, base := i.definitionSource(accumulator)
+	if accumulator.anonymous {
+		source = ""
+	}
+
+	for label, body := range words {
+		if label == name {
+			i.define(name, source, body)
+			i.words[name].base = base
+		} else {
+			i.words[label] = newDictionaryEntry(UserWord, body)
+		}
+	}
+	if accumulator.self != "" {
+		i.alias(accumulator.self, name)
+	}
+	i.context.Logger.Info("Defined [%s]", name)
+
+	if accumulator.anonymous {
+		return i.pushToken(name)
+	}
+
+	return nil
 }
 
-func record(i *ForthInterpreter, s string) {
-	i.newWordAccumulator.insert(s)
+// record adds a word to the definition being compiled, unless it is immediate
+// in which case it runs straight away.
+func record(i *ForthInterpreter, s string) error {
+	accumulator := i.newWordAccumulator
+	if accumulator.input != i.input {
+		accumulator.input = nil
+	}
+	if accumulator.label.isEmpty() {
+		accumulator.insert(s)
+		return nil
+	}
+
+	name, operand, _ := splitOperand(s)
+	entry, found := i.lookup(name)
+	if found && entry.compile != nil {
+		return entry.compile(i, operand)
+	}
+	if found && entry.immediate {
+		return executeCommand(i, s)
+	}
+
+	if !found && !compiler.IsCompilerWord(s) {
+		num, isNumber, err := i.parseLiteral(s, true)
+		if err != nil {
+			return err
+		}
+		if isNumber {
+			s = strconv.FormatInt(num, 10)
+		}
+	}
+
+	accumulator.insert(s)
+	return nil
 }
-func startRecording(i *ForthInterpreter, _ string) {
-	fmt.Println("Recording!!!")
+func startRecording(i *ForthInterpreter, _ string) error {
+	i.context.Logger.Debug("Recording a new definition")
+
+	base, err := i.context.Memory.Fetch(i.context.BaseAddress)
+	if err != nil {
+		return err
+	}
+
+	accumulator := i.newWordAccumulator
+	accumulator.base = base
+	if i.input != nil {
+		accumulator.input = i.input
+		accumulator.start = i.input.Offset()
+	}
 	i.handler = record
+	return i.setCompiling(true)
 }
 
-func (i *ForthInterpreter) Execute(s string) {
-	if s == ":" {
-		i.handler = startRecording
-	} else if s == ";" {
-		i.handler = endRecording
-	}
+// startAnonymousRecording is :NONAME, the definition gets a generated name and
+// its execution token is pushed once it is complete.
+func startAnonymousRecording(i *ForthInterpreter, s string) error {
+	i.newWordAccumulator.insert(fmt.Sprintf(":noname:%d", i.definitionCount+1))
+	i.newWordAccumulator.anonymous = true
 
-	i.handler(i, s)
+	return startRecording(i, s)
+}
+
+func (i *ForthInterpreter) execute(s string) error {
+	return i.handler(i, s)
+}
+
+// Execute runs a single word, returning an UndefinedWordError, WordError or
+// CompileError when it can not be completed.
+func (i *ForthInterpreter) Execute(s string) error {
+	return i.ExecuteContext(context.Background(), s)
+}
+
+// ExecuteContext is Execute, stopping with the context's error once it is done
+// or with a LimitExceededError once the configured Limits are reached.
+func (i *ForthInterpreter) ExecuteContext(ctx context.Context, s string) error {
+	return i.withExecution(ctx, func() error {
+		return i.execute(s)
+	})
+}
+
+// Evaluate tokenizes the given source and executes each word in turn, stopping at
+// the first failure which is returned wrapped in a SourceError.
+func (i *ForthInterpreter) Evaluate(source string) error {
+	return i.EvaluateContext(context.Background(), source)
+}
+
+// EvaluateContext is Evaluate with the whole source sharing one set of Limits.
+func (i *ForthInterpreter) EvaluateContext(ctx context.Context, source string) error {
+	return i.withExecution(ctx, func() error {
+		input := tokenizer.NewTokenizer(source)
+
+		previousInput := i.input
+		i.input = input
+		defer func() {
+			i.input = previousInput
+		}()
+
+		for {
+			token, found := input.Next()
+			if !found {
+				return nil
+			}
+
+			value := token.Value
+			if i.takesOperand(value) {
+				operand, found := input.Next()
+				if found {
+					value = joinOperand(value, operand.Value)
+				}
+			}
+
+			err := i.execute(value)
+			if err != nil {
+				return &SourceError{
+					Line:   token.Line,
+					Column: token.Column,
+					Err:    err,
+				}
+			}
+		}
+	})
 }
 
 type Label interface {
@@ -152,60 +429,253 @@ func NewPopulatedLabel(s string) Label {
 type newWordAccumulator struct {
 	label     Label
 	body      []string
-	wordCount int32
+	anonymous bool
+	// self is the hidden name RECURSE compiles to, it is empty until needed.
+	self string
+	// input is the source the definition is read from and start is the offset
+	// just past its ":", so that it can be saved as it was written. input is
+	// cleared once any part of the definition comes from anywhere else.
+	input tokenizer.Tokenizer
+	start int
+	// base is BASE when the definition started, its literals are in that base.
+	base int64
 }
 
 func (a *newWordAccumulator) insert(s string) {
 	if a.label.isEmpty() {
 		a.label = NewPopulatedLabel(s)
 	} else {
-		a.body[a.wordCount] = s
-		a.wordCount = a.wordCount + 1
+		a.body = append(a.body, s)
+	}
+}
+
+func (a *newWordAccumulator) source() string {
+	parts := []string{":", a.label.value()}
+	for _, word := range a.body {
+		if a.self != "" && word == a.self {
+			word = "recurse"
+		}
+		parts = append(parts, tokenizer.Source(stringSource(word)))
+	}
+
+	return strings.Join(append(parts, ";"), " ")
+}
+
+func (a *newWordAccumulator) copy() *newWordAccumulator {
+	return &newWordAccumulator{
+		label:     a.label,
+		body:      append([]string{}, a.body...),
+		anonymous: a.anonymous,
+		self:      a.self,
+		input:     a.input,
+		start:     a.start,
+		base:      a.base,
 	}
 }
 
 func NewWordAccumulator() *newWordAccumulator {
 	return &newWordAccumulator{
-		label:     EmptyLabel{},
-		body:      make([]string, 100),
-		wordCount: 0,
+		label: EmptyLabel{},
 	}
 }
 
-func wrapNative(name string, fun func(*stacks.ForthStack) error) func(*stacks.ForthStack, stacks.StringStack) error {
-	return func(forthStack *stacks.ForthStack, executionStack stacks.StringStack) error {
-		fmt.Printf("Calling through to native function, [%s]\n", name)
-		return fun(forthStack)
+func wrapNative(name string, fun words.NativeWord) words.Word {
+	return func(executionContext *words.ExecutionContext, executionStack stacks.StringStack) error {
+		executionContext.Logger.Trace("Calling through to native function, [%s]", name)
+		return fun(executionContext)
 	}
 }
 
-func wrapPredefined(name string, body []string) func(*stacks.ForthStack, stacks.StringStack) error {
-	return func(forthStack *stacks.ForthStack, executionStack stacks.StringStack) error {
+func wrapPredefined(name string, body []string) words.Word {
+	return func(executionContext *words.ExecutionContext, executionStack stacks.StringStack) error {
 		for _, w := range body {
-			fmt.Println(w)
+			executionContext.Logger.Trace("Expanding [%s] -> [%s]", name, w)
 			executionStack.Push(w)
 		}
 		return nil
 	}
 }
 
-func NewForthInterpreter() *ForthInterpreter {
+type interpreterConfig struct {
+	in     io.Reader
+	out    io.Writer
+	err    io.Writer
+	limits Limits
+	logger logging.Logger
+	tracer Tracer
+
+	debugController DebugController
+	rollback        RollbackMode
+	semantics       words.Semantics
+	division        words.Division
+	memorySize      int
+}
+
+type InterpreterOption func(*interpreterConfig)
+
+// WithInput sets where KEY reads from, the default is standard input.
+func WithInput(in io.Reader) InterpreterOption {
+	return func(config *interpreterConfig) {
+		config.in = in
+	}
+}
+
+// WithOutput directs everything a program prints to the given writer.
+func WithOutput(out io.Writer) InterpreterOption {
+	return func(config *interpreterConfig) {
+		config.out = out
+	}
+}
+
+// WithErrorOutput directs interpreter error reporting to the given writer.
+func WithErrorOutput(err io.Writer) InterpreterOption {
+	return func(config *interpreterConfig) {
+		config.err = err
+	}
+}
+
+// WithLimits bounds the steps and stack depths each run may use.
+func WithLimits(limits Limits) InterpreterOption {
+	return func(config *interpreterConfig) {
+		config.limits = limits
+	}
+}
+
+// WithLogger sets where diagnostic messages go, the default logger is silent.
+func WithLogger(logger logging.Logger) InterpreterOption {
+	return func(config *interpreterConfig) {
+		config.logger = logger
+	}
+}
+
+// WithTracer registers a tracer that is told about every word that runs.
+func WithTracer(tracer Tracer) InterpreterOption {
+	return func(config *interpreterConfig) {
+		config.tracer = tracer
+	}
+}
+
+// WithDebugController enables breakpoints, pausing through the given controller.
+func WithDebugController(controller DebugController) InterpreterOption {
+	return func(config *interpreterConfig) {
+		config.debugController = controller
+	}
+}
+
+// WithRollback makes failures non-destructive, see RollbackMode.
+func WithRollback(mode RollbackMode) InterpreterOption {
+	return func(config *interpreterConfig) {
+		config.rollback = mode
+	}
+}
+
+// WithSemantics picks between the legacy behaviour and standard Forth flags,
+// comparisons and printing, see words.Semantics.
+func WithSemantics(semantics words.Semantics) InterpreterOption {
+	return func(config *interpreterConfig) {
+		config.semantics = semantics
+	}
+}
+
+// WithDivision picks how /, MOD and friends round, see words.Division.
+func WithDivision(division words.Division) InterpreterOption {
+	return func(config *interpreterConfig) {
+		config.division = division
+	}
+}
+
+// systemCells is how many cells the interpreter reserves, one each for BASE and
+// STATE.
+const systemCells = 2
+
+// WithMemorySize sets how many cells memory has, BASE and STATE take the first
+// two and the rest are left for VARIABLE, ALLOT and friends. Any size below two,
+// including the zero value, means words.DefaultMemorySize.
+func WithMemorySize(cells int) InterpreterOption {
+	return func(config *interpreterConfig) {
+		config.memorySize = cells
+	}
+}
+
+func NewForthInterpreter(options ...InterpreterOption) *ForthInterpreter {
+	config := &interpreterConfig{
+		in:     os.Stdin,
+		out:    os.Stdout,
+		err:    os.Stderr,
+		logger: logging.NewSilentLogger(),
+	}
+	for _, option := range options {
+		option(config)
+	}
+
+	memorySize := config.memorySize
+	if memorySize < systemCells {
+		memorySize = words.DefaultMemorySize
+	}
+
+	stack := stacks.NewStack()
+	executionContext, setupErr := words.NewExecutionContextWithMemory(stack, config.out, config.err, config.logger, memory.NewMemory(memorySize))
+	executionContext.Semantics = config.semantics
+	executionContext.Division = config.division
+	executionContext.In = words.NewReaderInput(config.in)
+
 	nativeWords := words.NativeWords()
 	predefinedWords := words.PredefinedWords()
+	controlWords := words.ControlWords()
 
-	words := make(map[string]func(*stacks.ForthStack, stacks.StringStack) error)
+	words := make(map[string]*dictionaryEntry)
 	for key, value := range nativeWords {
-		words[key] = wrapNative(key, value)
+		words[key] = newDictionaryEntry(NativeWord, wrapNative(key, value))
+	}
+
+	for key, value := range controlWords {
+		words[key] = newDictionaryEntry(NativeWord, value)
 	}
 
 	for key, body := range predefinedWords {
-		words[key] = wrapPredefined(key, body)
+		words[key] = newDictionaryEntry(UserWord, wrapPredefined(key, body))
 	}
 
-	return &ForthInterpreter{
-		stack:              stacks.NewStack(),
+	interpreter := &ForthInterpreter{
+		stack:              stack,
+		context:            executionContext,
 		words:              words,
 		newWordAccumulator: NewWordAccumulator(),
 		handler:            executeCommand,
+		limits:             config.limits,
+		debugger:           newDebugger(),
+		rollback:           config.rollback,
+		tokens:             newExecutionTokens(),
+		setupErr:           setupErr,
 	}
+
+	executionContext.Step = func(word string) error {
+		if interpreter.execution == nil {
+			return nil
+		}
+
+		return interpreter.execution.count(word, stack)
+	}
+
+	for key, value := range interpreterWords() {
+		words[key] = newDictionaryEntry(NativeWord, wrapInterpreterWord(interpreter, value))
+	}
+	words["execute"] = newDictionaryEntry(NativeWord, interpreter.executeToken)
+	for key, value := range compilingWords(interpreter) {
+		words[key] = value
+	}
+	if interpreter.setupErr == nil {
+		interpreter.stateAddress, interpreter.setupErr = executionContext.Memory.Reserve(executionContext.Flag(false))
+	}
+
+	for key, value := range operandWords() {
+		words[key] = newOperandEntry(key, value)
+	}
+	for key, value := range stringWords() {
+		words[key] = value
+	}
+	interpreter.SetTracer(config.tracer)
+	interpreter.SetDebugController(config.debugController)
+
+	return interpreter
 }
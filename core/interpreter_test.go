@@ -0,0 +1,1272 @@
+package core_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"tim/forth/core"
+	"tim/forth/core/logging"
+	"tim/forth/core/support/memory"
+	"tim/forth/core/words"
+	"time"
+)
+
+func Test_ProgramOutput_goesToTheConfiguredWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithErrorOutput(out))
+
+	for _, word := range []string{"2", "3", "+", "print"} {
+		interpreter.Execute(word)
+	}
+
+	expected := "H -> [5] <- T"
+	if !strings.Contains(out.String(), expected) {
+		t.Error(fmt.Sprintf("Expected the output to contain [%s], instead got [%s]", expected, out.String()))
+	}
+}
+
+func executeAll(interpreter *core.ForthInterpreter, commands []string) error {
+	for _, word := range commands {
+		err := interpreter.Execute(word)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Test_UndefinedWord_returnsTypedError(t *testing.T) {
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}))
+
+	err := executeAll(interpreter, []string{"7", "not-a-word"})
+
+	var undefined *core.UndefinedWordError
+	if !errors.As(err, &undefined) {
+		t.Error(fmt.Sprintf("Expected an UndefinedWordError, instead got [%v]", err))
+		return
+	}
+
+	if undefined.Word != "not-a-word" {
+		t.Error(fmt.Sprintf("Expected the word [%s] but got [%s]", "not-a-word", undefined.Word))
+	}
+	if len(undefined.Stack) != 1 || undefined.Stack[0].ValueOf() != 7 {
+		t.Error(fmt.Sprintf("Expected the stack to be [7], instead got %v", undefined.Stack))
+	}
+}
+
+func Test_Underflow_isReachableThroughWordError(t *testing.T) {
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}))
+
+	err := executeAll(interpreter, []string{"1", "+"})
+
+	var wordError *core.WordError
+	if !errors.As(err, &wordError) || wordError.Word != "+" {
+		t.Error(fmt.Sprintf("Expected a WordError for [+], instead got [%v]", err))
+	}
+
+	if !errors.As(err, &words.UnderflowError{}) {
+		t.Error(fmt.Sprintf("Expected an UnderflowError, instead got [%v]", err))
+	}
+}
+
+func Test_IncompleteDefinition_returnsCompileError(t *testing.T) {
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}))
+
+	err := executeAll(interpreter, []string{":", "broken", "0", ">", "if", "1", ";"})
+
+	var compileError *core.CompileError
+	if !errors.As(err, &compileError) || compileError.Word != "broken" {
+		t.Error(fmt.Sprintf("Expected a CompileError for [broken], instead got [%v]", err))
+	}
+}
+
+func Test_Evaluate_runsMultiLineSource(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out))
+
+	err := interpreter.Evaluate(": cube\n\tdup  dup * * ;\n3 cube print")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "H -> [27] <- T"
+	if !strings.Contains(out.String(), expected) {
+		t.Error(fmt.Sprintf("Expected the output to contain [%s], instead got [%s]", expected, out.String()))
+	}
+}
+
+func Test_LongDefinition_isNotLimitedInLength(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(": count-up 0 " + strings.Repeat("1 + ", 150) + "; count-up .")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	if out.String() != "150 " {
+		t.Error(fmt.Sprintf("Expected [150 ], instead got [%s]", out.String()))
+	}
+}
+
+func Test_Evaluate_reportsWhereItFailed(t *testing.T) {
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}))
+
+	err := interpreter.Evaluate("1 2 +\n  3 nope")
+
+	var sourceError *core.SourceError
+	if !errors.As(err, &sourceError) {
+		t.Error(fmt.Sprintf("Expected a SourceError, instead got [%v]", err))
+		return
+	}
+
+	if sourceError.Line != 2 || sourceError.Column != 5 {
+		t.Error(fmt.Sprintf("Expected the failure at 2:5, instead got %d:%d", sourceError.Line, sourceError.Column))
+	}
+	if !errors.As(err, new(*core.UndefinedWordError)) {
+		t.Error("Expected the cause to be an UndefinedWordError")
+	}
+}
+
+func Test_RunawayRecursion_stopsAtTheStepLimit(t *testing.T) {
+	interpreter := core.NewForthInterpreter(
+		core.WithOutput(&bytes.Buffer{}),
+		core.WithLimits(core.Limits{MaxSteps: 1000}),
+	)
+
+	err := interpreter.Evaluate(": forever 1 drop forever ; forever")
+
+	var limitError *core.LimitExceededError
+	if !errors.As(err, &limitError) || limitError.Limit != "steps" {
+		t.Error(fmt.Sprintf("Expected the steps limit to be exceeded, instead got [%v]", err))
+	}
+}
+
+func Test_DataStackDepth_isLimited(t *testing.T) {
+	interpreter := core.NewForthInterpreter(
+		core.WithOutput(&bytes.Buffer{}),
+		core.WithLimits(core.Limits{MaxStackDepth: 3}),
+	)
+
+	err := interpreter.Evaluate("1 2 3 4")
+
+	var limitError *core.LimitExceededError
+	if !errors.As(err, &limitError) || limitError.Limit != "data stack depth" {
+		t.Error(fmt.Sprintf("Expected the data stack depth limit to be exceeded, instead got [%v]", err))
+	}
+}
+
+func Test_CancelledContext_stopsExecution(t *testing.T) {
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := interpreter.EvaluateContext(ctx, "1 2 +")
+	if !errors.Is(err, context.Canceled) {
+		t.Error(fmt.Sprintf("Expected the run to be cancelled, instead got [%v]", err))
+	}
+}
+
+func Test_Spaces_stopsAtTheStepLimit(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(
+		core.WithOutput(out),
+		core.WithLimits(core.Limits{MaxSteps: 1000}),
+	)
+
+	err := interpreter.Evaluate("9223372036854775807 spaces")
+
+	var limitError *core.LimitExceededError
+	if !errors.As(err, &limitError) || limitError.Limit != "steps" {
+		t.Error(fmt.Sprintf("Expected the steps limit to be exceeded, instead got [%v]", err))
+	}
+	if out.Len() > 64*1000 {
+		t.Error(fmt.Sprintf("Expected writing to stop at the limit, instead [%d] spaces were written", out.Len()))
+	}
+}
+
+func Test_Spaces_stopsWhenTheContextIsDone(t *testing.T) {
+	interpreter := core.NewForthInterpreter(core.WithOutput(io.Discard))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := interpreter.EvaluateContext(ctx, "9223372036854775807 spaces")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error(fmt.Sprintf("Expected the run to stop at the deadline, instead got [%v]", err))
+	}
+}
+
+func Test_DiagnosticLogging_isSilentByDefault(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out))
+
+	err := interpreter.Evaluate(": cube dup dup * * ; 2 cube drop")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+	}
+
+	if out.String() != "" {
+		t.Error(fmt.Sprintf("Expected no output, instead got [%s]", out.String()))
+	}
+}
+
+func Test_DiagnosticLogging_goesToTheConfiguredLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	log := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(
+		core.WithOutput(out),
+		core.WithLogger(logging.NewLogger(log, logging.Info)),
+	)
+
+	interpreter.Evaluate(": cube dup dup * * ;")
+
+	expected := "[INFO] Defined [cube]"
+	if !strings.Contains(log.String(), expected) {
+		t.Error(fmt.Sprintf("Expected the log to contain [%s], instead got [%s]", expected, log.String()))
+	}
+	if out.String() != "" {
+		t.Error(fmt.Sprintf("Expected no program output, instead got [%s]", out.String()))
+	}
+}
+
+type recordingTracer struct {
+	before []core.TraceEvent
+	after  []core.TraceEvent
+}
+
+func (r *recordingTracer) Before(event core.TraceEvent) {
+	r.before = append(r.before, event)
+}
+func (r *recordingTracer) After(event core.TraceEvent) {
+	r.after = append(r.after, event)
+}
+
+func Test_Tracer_isCalledAroundEveryWord(t *testing.T) {
+	tracer := &recordingTracer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}), core.WithTracer(tracer))
+
+	err := interpreter.Evaluate("3 square")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := []string{"3", "square", "dup", "*"}
+	if len(tracer.before) != len(expected) || len(tracer.after) != len(expected) {
+		t.Error(fmt.Sprintf("Expected [%d] events each side, instead got [%d] and [%d]", len(expected), len(tracer.before), len(tracer.after)))
+		return
+	}
+
+	for index, word := range expected {
+		if tracer.before[index].Word != word {
+			t.Error(fmt.Sprintf("Expected [%s] but got [%s]", word, tracer.before[index].Word))
+		}
+	}
+
+	if tracer.before[0].Kind != core.LiteralWord || tracer.before[1].Kind != core.UserWord || tracer.before[2].Kind != core.NativeWord {
+		t.Error("Expected the word kinds to be literal, user and then native")
+	}
+	if tracer.before[2].ExecutionDepth != 1 {
+		t.Error(fmt.Sprintf("Expected [dup] to run with [*] still pending, instead the depth was [%d]", tracer.before[2].ExecutionDepth))
+	}
+	if len(tracer.after[3].Stack) != 1 || tracer.after[3].Stack[0].ValueOf() != 9 {
+		t.Error(fmt.Sprintf("Expected the stack after [*] to be [9], instead got %v", tracer.after[3].Stack))
+	}
+}
+
+func Test_TraceWords_toggleTracingFromForth(t *testing.T) {
+	trace := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}), core.WithErrorOutput(trace))
+
+	err := interpreter.Evaluate("1 trace-on 2 trace-off 3")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	if !strings.Contains(trace.String(), "> 2 (literal)") {
+		t.Error(fmt.Sprintf("Expected [2] to be traced, instead got [%s]", trace.String()))
+	}
+	if strings.Contains(trace.String(), "> 1 ") || strings.Contains(trace.String(), "> 3 ") {
+		t.Error(fmt.Sprintf("Only words between trace-on and trace-off should be traced, got [%s]", trace.String()))
+	}
+}
+
+type scriptedController struct {
+	commands []core.DebugCommand
+	pauses   []core.DebugPause
+}
+
+func (s *scriptedController) Paused(pause core.DebugPause) core.DebugCommand {
+	s.pauses = append(s.pauses, pause)
+
+	if len(s.commands) == 0 {
+		return core.DebugContinue
+	}
+
+	command := s.commands[0]
+	s.commands = s.commands[1:]
+
+	return command
+}
+
+func pausedWords(pauses []core.DebugPause) []string {
+	result := []string{}
+	for _, pause := range pauses {
+		result = append(result, pause.Word)
+	}
+
+	return result
+}
+
+func Test_Debugger_stepsIntoAndOverWords(t *testing.T) {
+	controller := &scriptedController{
+		commands: []core.DebugCommand{core.DebugStep, core.DebugStepOver, core.DebugStep},
+	}
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}), core.WithDebugController(controller))
+
+	err := interpreter.Evaluate(": cube dup dup * * ; break cube 2 cube square")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := []string{"cube", "dup", "dup", "*"}
+	actual := pausedWords(controller.pauses)
+	if strings.Join(actual, " ") != strings.Join(expected, " ") {
+		t.Error(fmt.Sprintf("Expected to pause at %v, instead paused at %v", expected, actual))
+		return
+	}
+
+	if !controller.pauses[0].Breakpoint || controller.pauses[1].Breakpoint {
+		t.Error("Only the first pause should be reported as a breakpoint")
+	}
+	pending := strings.Join(controller.pauses[1].ExecutionStack, " ")
+	if pending != "dup * *" {
+		t.Error(fmt.Sprintf("Expected [dup * *] to be pending, instead got [%s]", pending))
+	}
+}
+
+func Test_Debugger_stepOverRunsTheWordToCompletion(t *testing.T) {
+	controller := &scriptedController{
+		commands: []core.DebugCommand{core.DebugStepOver},
+	}
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}), core.WithDebugController(controller))
+
+	err := interpreter.Evaluate(": cube dup dup * * ; : twice cube cube ; break cube 2 twice")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	actual := pausedWords(controller.pauses)
+	if strings.Join(actual, " ") != "cube cube" {
+		t.Error(fmt.Sprintf("Expected to pause at each cube, instead paused at %v", actual))
+		return
+	}
+
+	if controller.pauses[1].Stack[0].ValueOf() != 8 {
+		t.Error(fmt.Sprintf("Expected the first cube to have completed, instead the stack was %v", controller.pauses[1].Stack))
+	}
+}
+
+func Test_Debugger_abortStopsTheRun(t *testing.T) {
+	controller := &scriptedController{
+		commands: []core.DebugCommand{core.DebugAbort},
+	}
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}), core.WithDebugController(controller))
+
+	err := interpreter.Evaluate("break square 2 square")
+
+	if !errors.As(err, new(*core.DebugAbortedError)) {
+		t.Error(fmt.Sprintf("Expected a DebugAbortedError, instead got [%v]", err))
+	}
+}
+
+func Test_Restore_revertsTheStackAndDefinitions(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out))
+
+	interpreter.Evaluate("1 2")
+	snapshot := interpreter.Snapshot()
+
+	err := interpreter.Evaluate(": cube dup dup * * ; cube drop 5")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	interpreter.Restore(snapshot)
+	interpreter.Evaluate("print")
+
+	if !strings.Contains(out.String(), "H -> [2][1] <- T") {
+		t.Error(fmt.Sprintf("Expected the stack to be restored, instead got [%s]", out.String()))
+	}
+
+	err = interpreter.Evaluate("cube")
+	if !errors.As(err, new(*core.UndefinedWordError)) {
+		t.Error(fmt.Sprintf("Expected [cube] to no longer be defined, instead got [%v]", err))
+	}
+}
+
+func Test_Restore_revertsAPartialDefinition(t *testing.T) {
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}))
+
+	snapshot := interpreter.Snapshot()
+	interpreter.Evaluate(": half-written dup")
+	interpreter.Restore(snapshot)
+
+	err := interpreter.Evaluate("3 square")
+	if err != nil {
+		t.Error(fmt.Sprintf("Expected to be back to executing words, instead got [%v]", err))
+	}
+}
+
+func Test_Image_roundTripsUserWordsAndTheStack(t *testing.T) {
+	saved := &bytes.Buffer{}
+	original := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}))
+	original.Evaluate(": cube dup dup * * ; : twice-cubed cube cube ; 7 8")
+
+	err := original.SaveImage(saved, true)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error saving, got [%v]", err))
+		return
+	}
+
+	if strings.Contains(saved.String(), "fib") || strings.Contains(saved.String(), "\"dup\"") {
+		t.Error(fmt.Sprintf("Predefined and native words should not be saved, got [%s]", saved.String()))
+	}
+
+	out := &bytes.Buffer{}
+	restored := core.NewForthInterpreter(core.WithOutput(out))
+	err = restored.LoadImage(saved)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error loading, got [%v]", err))
+		return
+	}
+
+	restored.Evaluate("drop 2 twice-cubed print")
+
+	expected := "H -> [512][7] <- T"
+	if !strings.Contains(out.String(), expected) {
+		t.Error(fmt.Sprintf("Expected the output to contain [%s], instead got [%s]", expected, out.String()))
+	}
+}
+
+func Test_Image_keepsDefinitionsAsTheyWereWritten(t *testing.T) {
+	saved := &bytes.Buffer{}
+	original := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}), core.WithSemantics(words.StandardSemantics))
+	original.Evaluate(`
+		: cube ( n -- n^3 ) dup dup * * ; \ cubes a number
+		hex : sixteens 10 * ; decimal
+		: byte $ff ;
+	`)
+
+	err := original.SaveImage(saved, false)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error saving, got [%v]", err))
+		return
+	}
+
+	for _, text := range []string{"( n -- n^3 )", "$ff", "10 *"} {
+		if !strings.Contains(saved.String(), text) {
+			t.Error(fmt.Sprintf("Expected the image to contain [%s], instead got [%s]", text, saved.String()))
+		}
+	}
+
+	out := &bytes.Buffer{}
+	restored := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+	err = restored.LoadImage(saved)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error loading, got [%v]", err))
+		return
+	}
+
+	restored.Evaluate("3 cube . 2 sixteens . byte . 10 .")
+	if out.String() != "27 32 255 10 " {
+		t.Error(fmt.Sprintf("Expected [27 32 255 10 ], instead got [%s]", out.String()))
+	}
+}
+
+func Test_Image_withAnEmptyStackEmptiesTheStack(t *testing.T) {
+	saved := &bytes.Buffer{}
+	original := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}))
+	original.SaveImage(saved, true)
+
+	out := &bytes.Buffer{}
+	restored := core.NewForthInterpreter(core.WithOutput(out))
+	restored.Evaluate("7 8 9")
+	err := restored.LoadImage(saved)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error loading, got [%v]", err))
+		return
+	}
+	restored.Evaluate("print")
+
+	if !strings.Contains(out.String(), "H ->  <- T") {
+		t.Error(fmt.Sprintf("Expected the stack to be emptied, instead got [%s]", out.String()))
+	}
+}
+
+func Test_Image_keepsWhatVariablesAndValuesHold(t *testing.T) {
+	saved := &bytes.Buffer{}
+	original := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}), core.WithSemantics(words.StandardSemantics))
+	original.Evaluate("variable total 42 total ! 1 value limit 7 to limit")
+
+	err := original.SaveImage(saved, false)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error saving, got [%v]", err))
+		return
+	}
+
+	out := &bytes.Buffer{}
+	restored := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+	err = restored.LoadImage(saved)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error loading, got [%v]", err))
+		return
+	}
+
+	restored.Evaluate("total @ . limit .")
+	if out.String() != "42 7 " {
+		t.Error(fmt.Sprintf("Expected [42 7 ], instead got [%s]", out.String()))
+	}
+}
+
+func Test_Image_withoutTheStackLeavesTheStackAlone(t *testing.T) {
+	saved := &bytes.Buffer{}
+	original := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}))
+	original.Evaluate(": cube dup dup * * ; 7 8")
+	original.SaveImage(saved, false)
+
+	out := &bytes.Buffer{}
+	restored := core.NewForthInterpreter(core.WithOutput(out))
+	restored.Evaluate("1")
+	restored.LoadImage(saved)
+	restored.Evaluate("print")
+
+	if !strings.Contains(out.String(), "H -> [1] <- T") {
+		t.Error(fmt.Sprintf("Expected the stack to be untouched, instead got [%s]", out.String()))
+	}
+}
+
+func Test_RollbackWord_undoesAHalfFinishedWord(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithRollback(core.RollbackWord))
+
+	err := interpreter.Evaluate("1 +")
+	if err == nil {
+		t.Error("Expected [+] to underflow")
+	}
+
+	interpreter.Evaluate("print")
+	if !strings.Contains(out.String(), "H -> [1] <- T") {
+		t.Error(fmt.Sprintf("Expected the [1] popped by [+] to be restored, instead got [%s]", out.String()))
+	}
+}
+
+func Test_RollbackLine_undoesTheWholeFailingLine(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithRollback(core.RollbackLine))
+
+	interpreter.Evaluate("1")
+	err := interpreter.Evaluate("2 3 + nope 4")
+	if err == nil {
+		t.Error("Expected [nope] to be undefined")
+	}
+
+	interpreter.Evaluate("print")
+	if !strings.Contains(out.String(), "H -> [1] <- T") {
+		t.Error(fmt.Sprintf("Expected the stack from before the line, instead got [%s]", out.String()))
+	}
+}
+
+func Test_NoRollback_isTheDefault(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out))
+
+	interpreter.Evaluate("2 3 + nope")
+	interpreter.Evaluate("print")
+
+	if !strings.Contains(out.String(), "H -> [5] <- T") {
+		t.Error(fmt.Sprintf("Expected the stack to keep the work done before the failure, instead got [%s]", out.String()))
+	}
+}
+
+func Test_BeginAgain_doesNotGrowTheExecutionStack(t *testing.T) {
+	interpreter := core.NewForthInterpreter(
+		core.WithOutput(&bytes.Buffer{}),
+		core.WithLimits(core.Limits{MaxSteps: 10000, MaxExecutionDepth: 10}),
+	)
+
+	err := interpreter.Evaluate(": spin 0 begin 1 + again ; spin")
+
+	var limitError *core.LimitExceededError
+	if !errors.As(err, &limitError) || limitError.Limit != "steps" {
+		t.Error(fmt.Sprintf("Expected to run out of steps rather than execution stack, instead got [%v]", err))
+	}
+}
+
+func Test_StandardSemantics_consumeFlagsAndOperands(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate("10 3 - . 1 2 < . 2 1 < . 1 2 swap . . : sign 0 < IF -1 ELSE 1 THEN ; -5 sign . 5 sign . print")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "7 -1 0 1 2 -1 1 H ->  <- T\n"
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+}
+
+func Test_LegacySemantics_areTheDefault(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out))
+
+	interpreter.Evaluate("3 10 - 1 2 < print")
+
+	expected := "H -> [1][2][1][7] <- T"
+	if !strings.Contains(out.String(), expected) {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+}
+
+func Test_Memory_variablesConstantsAndValues(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		variable counter 5 counter ! 2 counter +! counter @ .
+		42 constant answer answer .
+		7 value limit : raise 9 to limit ; raise limit .
+		here 3 , 4 , here swap - .
+		300 counter c! counter c@ .
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "7 42 9 2 44 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+}
+
+func Test_Memory_outOfBoundsAddressIsATypedError(t *testing.T) {
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}), core.WithMemorySize(4))
+
+	err := interpreter.Evaluate("variable x 1000 @")
+	if !errors.As(err, new(*memory.InvalidAddressError)) {
+		t.Error(fmt.Sprintf("Expected an InvalidAddressError, instead got [%v]", err))
+	}
+
+	err = interpreter.Evaluate("10 allot")
+	if !errors.As(err, new(*memory.OutOfMemoryError)) {
+		t.Error(fmt.Sprintf("Expected an OutOfMemoryError, instead got [%v]", err))
+	}
+}
+
+func Test_MemorySizeBelowTheSystemCells_usesTheDefault(t *testing.T) {
+	for _, size := range []int{-1, 0, 1} {
+		out := &bytes.Buffer{}
+		interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics), core.WithMemorySize(size))
+
+		err := interpreter.Evaluate(fmt.Sprintf("%d allot here .", words.DefaultMemorySize-2))
+		if err != nil || out.String() != fmt.Sprintf("%d ", words.DefaultMemorySize) {
+			t.Error(fmt.Sprintf("Expected a size of [%d] to use the default memory, instead got [%s] with [%v]", size, out.String(), err))
+		}
+	}
+}
+
+func Test_Allot_cannotGiveBackTheSystemCells(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate("-2 allot")
+	if !errors.As(err, new(*memory.InvalidAddressError)) {
+		t.Error(fmt.Sprintf("Expected an InvalidAddressError, instead got [%v]", err))
+	}
+
+	err = interpreter.Evaluate("variable x 5 x ! x @ . state @ .")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	if out.String() != "5 0 " {
+		t.Error(fmt.Sprintf("Expected [5 0 ], instead got [%s]", out.String()))
+	}
+}
+
+func Test_To_rejectsWordsThatAreNotValues(t *testing.T) {
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}))
+
+	err := interpreter.Evaluate("1 constant one 2 to one")
+
+	var invalid *words.InvalidArgument
+	if !errors.As(err, &invalid) {
+		t.Error(fmt.Sprintf("Expected an InvalidArgument, instead got [%v]", err))
+	}
+}
+
+func Test_CreateDoes_buildsDefiningWords(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		: array create cells allot does> + ;
+		3 array squares
+		4 1 squares ! 9 2 squares !
+		1 squares @ . 2 squares @ .
+		: counter create , does> dup 1 swap +! @ ;
+		10 counter ticks ticks . ticks .
+		create table 5 , 6 , table cell+ @ .
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "4 9 11 12 6 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+}
+
+func Test_CreateDoes_refusesToBeSavedInAnImage(t *testing.T) {
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}), core.WithSemantics(words.StandardSemantics))
+	interpreter.Evaluate(": counter create , does> dup 1 swap +! @ ; 10 counter ticks")
+
+	err := interpreter.SaveImage(&bytes.Buffer{}, false)
+
+	var unsavable *core.UnsavableWordError
+	if !errors.As(err, &unsavable) || unsavable.Word != "ticks" {
+		t.Error(fmt.Sprintf("Expected an UnsavableWordError for [ticks], instead got [%v]", err))
+	}
+}
+
+func Test_ReturnStack_holdsTemporaryValues(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		: under+ >r + r> ;
+		: pair 2>r r@ . 2r> . . ;
+		1 2 3 under+ . .
+		4 5 pair
+		6 >r 7 r> . .
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "3 3 5 5 4 6 7 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+}
+
+func Test_ReturnStack_mustBeBalancedWhenADefinitionEnds(t *testing.T) {
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}))
+
+	err := interpreter.Evaluate(": leaky >r ; : outer 1 leaky ; outer")
+
+	var unbalanced *core.UnbalancedReturnStackError
+	if !errors.As(err, &unbalanced) || unbalanced.Word != "leaky" {
+		t.Error(fmt.Sprintf("Expected an UnbalancedReturnStackError for [leaky], instead got [%v]", err))
+	}
+
+	err = interpreter.Evaluate("r>")
+
+	var underflow words.ReturnUnderflowError
+	if !errors.As(err, &underflow) {
+		t.Error(fmt.Sprintf("Expected a ReturnUnderflowError, instead got [%v]", err))
+	}
+}
+
+func Test_ExecutionTokens_runWordsIndirectly(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		: square dup * ;
+		3 ' square execute .
+		: apply-twice dup >r execute r> execute ;
+		2 ['] square apply-twice .
+		: square 0 ; 4 ' square execute .
+		:noname 1 + ; 9 swap execute .
+		variable handler ' dup handler ! 5 handler @ execute . .
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "9 16 0 10 5 5 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+}
+
+func Test_ExecutionTokens_keepTheWordTheyWereTakenFrom(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(": greet 1 ; ' greet : greet 2 ; execute . greet .")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	if out.String() != "1 2 " {
+		t.Error(fmt.Sprintf("Expected [1 2 ] but got [%s]", out.String()))
+	}
+
+	err = interpreter.Evaluate("42 execute")
+
+	var invalid *words.InvalidArgument
+	if !errors.As(err, &invalid) {
+		t.Error(fmt.Sprintf("Expected an InvalidArgument, instead got [%v]", err))
+	}
+}
+
+func Test_ExecutionTokens_areSavedInImagesByName(t *testing.T) {
+	saved := &bytes.Buffer{}
+	original := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}), core.WithSemantics(words.StandardSemantics))
+	original.Evaluate("' dup constant d : square dup * ; ' square")
+
+	err := original.SaveImage(saved, true)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error saving, got [%v]", err))
+		return
+	}
+
+	out := &bytes.Buffer{}
+	restored := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+	restored.Evaluate("' swap drop")
+	err = restored.LoadImage(saved)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error loading, got [%v]", err))
+		return
+	}
+
+	restored.Evaluate("3 swap execute . 4 d execute . .")
+	if out.String() != "9 4 4 " {
+		t.Error(fmt.Sprintf("Expected [9 4 4 ], instead got [%s]", out.String()))
+	}
+}
+
+func Test_ExecutionTokens_withoutANameCanNotBeSaved(t *testing.T) {
+	interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}), core.WithSemantics(words.StandardSemantics))
+
+	interpreter.Evaluate(":noname 1 ; constant one")
+	err := interpreter.SaveImage(&bytes.Buffer{}, false)
+	if !errors.As(err, new(*core.UnsavableWordError)) {
+		t.Error(fmt.Sprintf("Expected an UnsavableWordError for a constant, instead got [%v]", err))
+	}
+
+	other := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}), core.WithSemantics(words.StandardSemantics))
+	other.Evaluate(": greet 1 ; ' greet : greet 2 ;")
+	err = other.SaveImage(&bytes.Buffer{}, true)
+	var unsavable *core.UnsavableWordError
+	if !errors.As(err, &unsavable) || unsavable.Word != "greet" {
+		t.Error(fmt.Sprintf("Expected an UnsavableWordError for the stack, instead got [%v]", err))
+	}
+}
+
+func Test_ImmediateWords_extendTheCompiler(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		: unless postpone 0= postpone if ; immediate
+		: check unless 1 . else 2 . then ;
+		0 check 5 check
+		: six [ 2 3 * ] literal ; six .
+		: compiling? state @ ; immediate
+		: during compiling? literal ; during . state @ .
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "1 2 6 -1 0 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+}
+
+func Test_ReadmeUnless_runsWithTheNativeZeroEquals(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		: unless postpone 0= postpone if ; immediate
+		: zero? unless ." zero" then ;
+		0 zero? 3 zero?
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	if out.String() != "zero" {
+		t.Error(fmt.Sprintf("Expected [zero], instead got [%s]", out.String()))
+	}
+}
+
+func Test_Postpone_compilesImmediateWords(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		: shout 7 . ; immediate
+		: later postpone shout ;
+		: now shout ;
+		later
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	if out.String() != "7 7 " {
+		t.Error(fmt.Sprintf("Expected [shout] to run once while compiling and once from [later], instead got [%s]", out.String()))
+	}
+}
+
+func Test_Recurse_callsTheWordBeingDefined(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		: factorial dup 1 > if dup 1 - factorial * then ;
+		: factorial dup 2 < if drop 1 else dup 1 - recurse * then ;
+		5 factorial .
+		:noname dup 0 > if dup . 1 - recurse else drop then ; 3 swap execute
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "120 3 2 1 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+
+	saved := &bytes.Buffer{}
+	interpreter.SaveImage(saved, false)
+	if !strings.Contains(saved.String(), "dup 1 - recurse *") {
+		t.Error(fmt.Sprintf("Expected the image to keep RECURSE in the source, instead got [%s]", saved.String()))
+	}
+}
+
+func Test_Exit_leavesOnlyTheCurrentDefinition(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		: sign dup 0 < if drop -1 exit then 0 > if 1 exit then 0 ;
+		: show sign . 9 . ;
+		-4 show 0 show 7 show
+		: first-over 10 0 do i 3 > if i exit then loop -1 ;
+		first-over .
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "-1 9 0 9 1 9 4 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+
+	// J only works if the loop first-over left by EXIT is still running
+	err = interpreter.Evaluate(": probe 1 0 do j loop ; first-over drop probe")
+
+	var controlFlow *words.ControlFlowError
+	if !errors.As(err, &controlFlow) {
+		t.Error(fmt.Sprintf("Expected EXIT to have ended the loop, instead got [%v]", err))
+	}
+
+	err = interpreter.Evaluate("exit")
+	if !errors.As(err, &controlFlow) {
+		t.Error(fmt.Sprintf("Expected a ControlFlowError, instead got [%v]", err))
+	}
+}
+
+func Test_Comments_areIgnoredInsideAndOutsideDefinitions(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		\ a cube, defined over
+		\ a few lines
+		: cube ( n -- n^3 )
+			dup dup * * \ three times over
+		;
+		( the result ) 3 cube .
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	if out.String() != "27 " {
+		t.Error(fmt.Sprintf("Expected [27 ] but got [%s]", out.String()))
+	}
+}
+
+func Test_Strings_printAndLiveInMemory(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		: greet ." Hello, " type ." !" ;
+		s" world" greet
+		: name c" forth" ;
+		name count type
+		: same 3 0 do s" x" drop drop loop here ; same same = .
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "Hello, world!forth-1 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+
+	saved := &bytes.Buffer{}
+	interpreter.SaveImage(saved, false)
+
+	restored := &bytes.Buffer{}
+	other := core.NewForthInterpreter(core.WithOutput(restored), core.WithSemantics(words.StandardSemantics))
+	err = other.LoadImage(saved)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error loading the image, got [%v]", err))
+		return
+	}
+
+	other.Evaluate(`s" again" greet`)
+	if restored.String() != "Hello, again!" {
+		t.Error(fmt.Sprintf("Expected the strings to survive the image, instead got [%s]", restored.String()))
+	}
+}
+
+func Test_Strings_doNotAllotWhenTheyRun(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		: greet s" hi" type ;
+		create u 1 , greet 2 , u 1 + @ .
+		10 allot s" hello" -5 allot variable a 66 a ! type
+		: name c" forth" ; here name count type here = .
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "hi2 helloforth-1 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+}
+
+func Test_Type_rejectsLengthsOutsideMemory(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate("0 -1 type")
+	if !errors.As(err, new(*words.InvalidArgument)) {
+		t.Error(fmt.Sprintf("Expected an InvalidArgument for a negative length, instead got [%v]", err))
+	}
+
+	err = interpreter.Evaluate("0 1000000000000000 type")
+	if !errors.As(err, new(*memory.InvalidAddressError)) {
+		t.Error(fmt.Sprintf("Expected an InvalidAddressError for a huge length, instead got [%v]", err))
+	}
+
+	if out.String() != "" {
+		t.Error(fmt.Sprintf("Expected nothing to be printed, instead got [%s]", out.String()))
+	}
+}
+
+func Test_CharacterIO_usesTheConfiguredInputAndOutput(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(
+		core.WithInput(strings.NewReader("hi")),
+		core.WithOutput(out),
+		core.WithSemantics(words.StandardSemantics),
+	)
+
+	err := interpreter.Evaluate(`
+		: echo key? if key emit recurse then ;
+		65 emit space 66 emit 3 spaces 67 emit cr
+		echo key? .
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "A B   C\nhi0 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+
+	err = interpreter.Evaluate("key")
+	if !errors.Is(err, io.EOF) {
+		t.Error(fmt.Sprintf("Expected to run out of input, instead got [%v]", err))
+	}
+}
+
+var errOutputFull = errors.New("output full")
+
+// fullWriter accepts a limited number of bytes and then fails every write.
+type fullWriter struct {
+	written int
+	limit   int
+}
+
+func (w *fullWriter) Write(p []byte) (int, error) {
+	if w.written+len(p) > w.limit {
+		return 0, errOutputFull
+	}
+
+	w.written = w.written + len(p)
+	return len(p), nil
+}
+
+func Test_Spaces_writesLargeCountsInPieces(t *testing.T) {
+	out := &fullWriter{limit: 1000}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate("1000000000000000 spaces")
+	if !errors.Is(err, errOutputFull) {
+		t.Error(fmt.Sprintf("Expected the writer's error, instead got [%v]", err))
+	}
+
+	if out.written == 0 || out.written > 1000 {
+		t.Error(fmt.Sprintf("Expected the spaces to be written in pieces up to the limit, instead [%d] were written", out.written))
+	}
+}
+
+func Test_NumberBases_readAndPrintLiterals(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		$ff . #10 . %1010 . 'a' . $-10 .
+		hex ff . 10 . -1 u. decimal
+		: sixteen 10 ; : mask $0f ; hex sixteen . mask . : ten a ; decimal ten .
+		binary 101 . decimal 2 base ! 11 1 + . decimal
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "255 10 10 97 -16 FF 10 FFFFFFFFFFFFFFFF A F 10 101 100 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+}
+
+func Test_NumberBases_wordsWinOverNumbers(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(": add + ; hex 1 2 add . decimal")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	if out.String() != "3 " {
+		t.Error(fmt.Sprintf("Expected [3 ] but got [%s]", out.String()))
+	}
+
+	err = interpreter.Evaluate("1 base ! 5 .")
+
+	var invalid *words.InvalidArgument
+	if !errors.As(err, &invalid) {
+		t.Error(fmt.Sprintf("Expected an InvalidArgument for BASE 1, instead got [%v]", err))
+	}
+}
+
+func Test_Arithmetic_fullWordset(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(core.WithOutput(out), core.WithSemantics(words.StandardSemantics))
+
+	err := interpreter.Evaluate(`
+		7 2 / . 7 2 mod . 7 2 /mod . .
+		-7 2 / . -7 2 mod .
+		1000000000000 3000000 2000000 */ . 10 3 7 */mod . .
+		5 negate . -5 abs . 3 8 min . 3 8 max .
+		1 1+ . 1 1- . 3 2* . -7 2/ .
+	`)
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "3 1 3 1 -3 -1 1500000000000 4 2 -5 5 3 8 2 0 6 -4 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+}
+
+func Test_Arithmetic_flooredDivision(t *testing.T) {
+	out := &bytes.Buffer{}
+	interpreter := core.NewForthInterpreter(
+		core.WithOutput(out),
+		core.WithSemantics(words.StandardSemantics),
+		core.WithDivision(words.FlooredDivision),
+	)
+
+	err := interpreter.Evaluate("-7 2 / . -7 2 mod . 7 -2 /mod . . -7 1 2 */mod . .")
+	if err != nil {
+		t.Error(fmt.Sprintf("Did not expect an error, got [%v]", err))
+		return
+	}
+
+	expected := "-4 1 -4 -1 -4 1 "
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+}
+
+func Test_Arithmetic_divisionByZeroIsATypedError(t *testing.T) {
+	for _, source := range []string{"1 0 /", "1 0 mod", "1 0 /mod", "1 2 0 */", "1 2 0 */mod"} {
+		interpreter := core.NewForthInterpreter(core.WithOutput(&bytes.Buffer{}))
+
+		err := interpreter.Evaluate(source)
+
+		var divisionByZero words.DivisionByZeroError
+		if !errors.As(err, &divisionByZero) {
+			t.Error(fmt.Sprintf("Expected a DivisionByZeroError from [%s], instead got [%v]", source, err))
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"fmt"
+	"tim/forth/core/compiler"
+	"tim/forth/core/support/stacks"
+	"tim/forth/core/words"
+)
+
+// interpreterWords are native words that need the interpreter itself rather than
+// just the execution context.
+func interpreterWords() map[string]func(*ForthInterpreter) error {
+	result := make(map[string]func(*ForthInterpreter) error)
+
+	result["trace-on"] = traceOn
+	result["trace-off"] = traceOff
+	result["break"] = parsing("break", setBreakpoint)
+	result["unbreak"] = parsing("unbreak", clearBreakpoint)
+	result["save-image"] = parsing("save-image", saveImageFile(false))
+	result["save-image-with-stack"] = parsing("save-image-with-stack", saveImageFile(true))
+	result["load-image"] = parsing("load-image", loadImageFile)
+	result["variable"] = parsing("variable", defineVariable)
+	result["constant"] = parsing("constant", defineConstant)
+	result["value"] = parsing("value", defineValue)
+	result["create"] = parsing("create", create)
+	result["'"] = parsing("'", (*ForthInterpreter).pushToken)
+
+	return result
+}
+
+// operandWords take the word that follows them as an operand, unlike parsing
+// words this happens as the source is read so it works inside definitions too.
+func operandWords() map[string]func(*ForthInterpreter, string) error {
+	result := make(map[string]func(*ForthInterpreter, string) error)
+
+	result["to"] = storeValue
+	result[compiler.DoesWord] = does
+	result["[']"] = (*ForthInterpreter).pushToken
+
+	return result
+}
+
+func newOperandEntry(name string, fun func(*ForthInterpreter, string) error) *dictionaryEntry {
+	entry := newDictionaryEntry(NativeWord, func(executionContext *words.ExecutionContext, executionStack stacks.StringStack) error {
+		return words.NewInvalidArgument(fmt.Sprintf("[%s] expects a name to follow it", name))
+	})
+	entry.operand = fun
+
+	return entry
+}
+
+func wrapInterpreterWord(i *ForthInterpreter, fun func(*ForthInterpreter) error) words.Word {
+	return func(executionContext *words.ExecutionContext, executionStack stacks.StringStack) error {
+		return fun(i)
+	}
+}
+
+// nextName reads the word following a parsing word, such as the word to break on
+// in `break fibto`, from the source currently being evaluated.
+func (i *ForthInterpreter) nextName(parsingWord string) (string, error) {
+	if i.input != nil {
+		token, found := i.input.Next()
+		if found {
+			return token.Value, nil
+		}
+	}
+
+	return "", words.NewInvalidArgument(fmt.Sprintf("[%s] expects a name to follow it", parsingWord))
+}
+
+func parsing(name string, fun func(*ForthInterpreter, string) error) func(*ForthInterpreter) error {
+	return func(i *ForthInterpreter) error {
+		parsed, err := i.nextName(name)
+		if err != nil {
+			return err
+		}
+
+		return fun(i, parsed)
+	}
+}
@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+type Level int
+
+const (
+	Silent Level = iota
+	Info
+	Debug
+	Trace
+)
+
+func (l Level) ToString() string {
+	switch l {
+	case Silent:
+		return "SILENT"
+	case Info:
+		return "INFO"
+	case Debug:
+		return "DEBUG"
+	case Trace:
+		return "TRACE"
+	}
+
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+func ParseLevel(s string) (Level, error) {
+	for _, level := range []Level{Silent, Info, Debug, Trace} {
+		if strings.EqualFold(s, level.ToString()) {
+			return level, nil
+		}
+	}
+
+	return Silent, fmt.Errorf("unknown log level [%s]", s)
+}
+
+type Logger interface {
+	Info(format string, args ...interface{})
+	Debug(format string, args ...interface{})
+	Trace(format string, args ...interface{})
+	Level() Level
+}
+
+type writerLogger struct {
+	out   io.Writer
+	level Level
+}
+
+func (l *writerLogger) log(level Level, format string, args []interface{}) {
+	if level > l.level {
+		return
+	}
+
+	fmt.Fprintf(l.out, "[%s] %s\n", level.ToString(), fmt.Sprintf(format, args...))
+}
+func (l *writerLogger) Info(format string, args ...interface{}) {
+	l.log(Info, format, args)
+}
+func (l *writerLogger) Debug(format string, args ...interface{}) {
+	l.log(Debug, format, args)
+}
+func (l *writerLogger) Trace(format string, args ...interface{}) {
+	l.log(Trace, format, args)
+}
+func (l *writerLogger) Level() Level {
+	return l.level
+}
+
+// NewLogger writes every message at or below the given level to out.
+func NewLogger(out io.Writer, level Level) Logger {
+	return &writerLogger{
+		out:   out,
+		level: level,
+	}
+}
+
+func NewSilentLogger() Logger {
+	return NewLogger(ioutil.Discard, Silent)
+}
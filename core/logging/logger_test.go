@@ -0,0 +1,34 @@
+package logging_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"tim/forth/core/logging"
+)
+
+func Test_MessagesAboveTheLevel_areDropped(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := logging.NewLogger(out, logging.Debug)
+
+	logger.Info("info %d", 1)
+	logger.Debug("debug %d", 2)
+	logger.Trace("trace %d", 3)
+
+	expected := "[INFO] info 1\n[DEBUG] debug 2\n"
+	if out.String() != expected {
+		t.Error(fmt.Sprintf("Expected [%s] but got [%s]", expected, out.String()))
+	}
+}
+
+func Test_SilentLogger_writesNothing(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := logging.NewLogger(out, logging.Silent)
+
+	logger.Info("info")
+
+	if strings.TrimSpace(out.String()) != "" {
+		t.Error(fmt.Sprintf("Expected no output, instead got [%s]", out.String()))
+	}
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"tim/forth/core/support/memory"
+	"tim/forth/core/support/stacks"
+)
+
+// Snapshot is a point in time copy of an interpreter's data stack, dictionary
+// and any definition that is part way through being recorded.
+type Snapshot struct {
+	stack              stacks.StackSnapshot
+	memory             memory.MemorySnapshot
+	words              map[string]*dictionaryEntry
+	handler            func(*ForthInterpreter, string) error
+	newWordAccumulator *newWordAccumulator
+}
+
+func copyWords(words map[string]*dictionaryEntry) map[string]*dictionaryEntry {
+	result := make(map[string]*dictionaryEntry, len(words))
+	for key, entry := range words {
+		result[key] = entry
+	}
+
+	return result
+}
+
+func (i *ForthInterpreter) Snapshot() *Snapshot {
+	return &Snapshot{
+		stack:              i.stack.Snapshot(),
+		memory:             i.context.Memory.Snapshot(),
+		words:              copyWords(i.words),
+		handler:            i.handler,
+		newWordAccumulator: i.newWordAccumulator.copy(),
+	}
+}
+
+// Restore puts the interpreter back to the state it was in when the snapshot was
+// taken, the snapshot itself is left untouched so it can be restored again.
+func (i *ForthInterpreter) Restore(snapshot *Snapshot) {
+	i.stack.Restore(snapshot.stack)
+	i.context.Memory.Restore(snapshot.memory)
+	i.words = copyWords(snapshot.words)
+	i.handler = snapshot.handler
+	i.newWordAccumulator = snapshot.newWordAccumulator.copy()
+}
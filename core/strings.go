@@ -0,0 +1,134 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"tim/forth/core/support/stacks"
+)
+
+// compiledString and compiledCountedString are what S" and C" become inside a
+// definition, their operand is the address the text was stored at followed by
+// the text itself.
+const (
+	compiledString        = "(s\")"
+	compiledCountedString = "(c\")"
+)
+
+// stringCells is text one byte per cell, counted strings start with a cell
+// holding the length.
+func stringCells(text string, counted bool) []int64 {
+	cells := []int64{}
+	if counted {
+		cells = append(cells, int64(len(text)))
+	}
+	for _, b := range []byte(text) {
+		cells = append(cells, int64(b))
+	}
+
+	return cells
+}
+
+// stringWords print text and leave it in memory, typed in the text only lasts
+// until the next string while in a definition it is stored as the definition is
+// compiled and kept for good.
+func stringWords() map[string]*dictionaryEntry {
+	result := make(map[string]*dictionaryEntry)
+
+	result[".\""] = newOperandEntry(".\"", printString)
+
+	pushText := newOperandEntry("s\"", pushString)
+	pushText.compile = compileString(false)
+	result["s\""] = pushText
+
+	pushCounted := newOperandEntry("c\"", pushCountedString)
+	pushCounted.compile = compileString(true)
+	result["c\""] = pushCounted
+
+	result[compiledString] = newOperandEntry(compiledString, pushCompiledString(false))
+	result[compiledCountedString] = newOperandEntry(compiledCountedString, pushCompiledString(true))
+
+	return result
+}
+
+func printString(i *ForthInterpreter, text string) error {
+	_, err := fmt.Fprint(i.context.Out, text)
+	return err
+}
+
+// pushString is S", leaving the address and length of the text.
+func pushString(i *ForthInterpreter, text string) error {
+	address := i.context.Memory.Transient(stringCells(text, false))
+
+	i.stack.Push(stacks.Number{Value: address})
+	i.stack.Push(stacks.Number{Value: int64(len(text))})
+	return nil
+}
+
+// pushCountedString is C", leaving the address of the length cell.
+func pushCountedString(i *ForthInterpreter, text string) error {
+	address := i.context.Memory.Transient(stringCells(text, true))
+
+	i.stack.Push(stacks.Number{Value: address})
+	return nil
+}
+
+// compileString stores the text of S" or C" at HERE while the definition is
+// compiled, so running it never allots anything.
+func compileString(counted bool) func(*ForthInterpreter, string) error {
+	return func(i *ForthInterpreter, text string) error {
+		memory := i.context.Memory
+		address := memory.Here()
+		for _, cell := range stringCells(text, counted) {
+			err := memory.Comma(cell)
+			if err != nil {
+				return err
+			}
+		}
+
+		word := compiledString
+		if counted {
+			word = compiledCountedString
+		}
+
+		i.newWordAccumulator.insert(joinOperand(word, fmt.Sprintf("%d %s", address, text)))
+		return nil
+	}
+}
+
+func splitCompiledString(operand string) (int64, string) {
+	parts := strings.SplitN(operand, " ", 2)
+	address, _ := strconv.ParseInt(parts[0], 10, 64)
+	if len(parts) < 2 {
+		return address, ""
+	}
+
+	return address, parts[1]
+}
+
+func pushCompiledString(counted bool) func(*ForthInterpreter, string) error {
+	return func(i *ForthInterpreter, operand string) error {
+		address, text := splitCompiledString(operand)
+
+		i.stack.Push(stacks.Number{Value: address})
+		if !counted {
+			i.stack.Push(stacks.Number{Value: int64(len(text))})
+		}
+		return nil
+	}
+}
+
+// stringSource turns a compiled string back into the S" or C" that made it.
+func stringSource(word string) string {
+	name, operand, _ := splitOperand(word)
+	switch name {
+	case compiledString:
+		_, text := splitCompiledString(operand)
+		return joinOperand("s\"", text)
+	case compiledCountedString:
+		_, text := splitCompiledString(operand)
+		return joinOperand("c\"", text)
+	}
+
+	return word
+}
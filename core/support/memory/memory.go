@@ -0,0 +1,160 @@
+package memory
+
+import "fmt"
+
+// InvalidAddressError is returned for any access outside of the memory that has
+// been allotted so far.
+type InvalidAddressError struct {
+	Address int64
+	Here    int64
+}
+
+func (e *InvalidAddressError) Error() string {
+	return fmt.Sprintf("Invalid address [%d], only [0, %d) has been allotted", e.Address, e.Here)
+}
+
+type OutOfMemoryError struct {
+	Requested int64
+	Available int64
+}
+
+func (e *OutOfMemoryError) Error() string {
+	return fmt.Sprintf("Out of memory, requested [%d] cells with [%d] available", e.Requested, e.Available)
+}
+
+// Memory is a cell addressed data space, address n is the nth cell and each cell
+// holds a whole int64. Space is handed out from the bottom up, HERE being the
+// address of the next free cell. Cells below the floor have been reserved and
+// can never be given back.
+type Memory struct {
+	cells []int64
+	here  int64
+	floor int64
+	// transient is a scratch area just past the cells ALLOT can reach, see
+	// Transient.
+	transient []int64
+}
+
+func (m *Memory) cell(address int64) (*int64, error) {
+	if address >= 0 && address < m.here {
+		return &m.cells[address], nil
+	}
+
+	offset := address - int64(len(m.cells))
+	if offset >= 0 && offset < int64(len(m.transient)) {
+		return &m.transient[offset], nil
+	}
+
+	return nil, &InvalidAddressError{
+		Address: address,
+		Here:    m.here,
+	}
+}
+
+func (m *Memory) Fetch(address int64) (int64, error) {
+	cell, err := m.cell(address)
+	if err != nil {
+		return 0, err
+	}
+
+	return *cell, nil
+}
+func (m *Memory) Store(address int64, value int64) error {
+	cell, err := m.cell(address)
+	if err != nil {
+		return err
+	}
+
+	*cell = value
+
+	return nil
+}
+
+func (m *Memory) Here() int64 {
+	return m.here
+}
+
+// Allot moves HERE on by n cells, a negative n gives space back down to the
+// reserved cells. New space is always zeroed.
+func (m *Memory) Allot(n int64) error {
+	next := m.here + n
+	if next > int64(len(m.cells)) {
+		return &OutOfMemoryError{
+			Requested: n,
+			Available: int64(len(m.cells)) - m.here,
+		}
+	}
+	if next < m.floor {
+		return &InvalidAddressError{
+			Address: next,
+			Here:    m.here,
+		}
+	}
+
+	for address := m.here; address < next; address++ {
+		m.cells[address] = 0
+	}
+	m.here = next
+
+	return nil
+}
+
+// Comma allots one cell and stores the value in it.
+func (m *Memory) Comma(value int64) error {
+	address := m.here
+
+	err := m.Allot(1)
+	if err != nil {
+		return err
+	}
+
+	return m.Store(address, value)
+}
+
+// Reserve commas a cell that ALLOT can not give back, for the cells the system
+// itself depends on, and returns its address.
+func (m *Memory) Reserve(value int64) (int64, error) {
+	address := m.here
+
+	err := m.Comma(value)
+	if err != nil {
+		return 0, err
+	}
+	m.floor = m.here
+
+	return address, nil
+}
+
+// Transient copies values into the scratch area and returns its address. The
+// area is outside ALLOT's reach so nothing allotted can overlap it, but each
+// call replaces what the last one put there and snapshots do not keep it.
+func (m *Memory) Transient(values []int64) int64 {
+	m.transient = append(m.transient[:0], values...)
+
+	return int64(len(m.cells))
+}
+
+type MemorySnapshot struct {
+	cells []int64
+	floor int64
+}
+
+// Snapshot copies the allotted part of memory.
+func (m *Memory) Snapshot() MemorySnapshot {
+	cells := make([]int64, m.here)
+	copy(cells, m.cells[:m.here])
+
+	return MemorySnapshot{cells: cells, floor: m.floor}
+}
+func (m *Memory) Restore(snapshot MemorySnapshot) {
+	copy(m.cells, snapshot.cells)
+	m.here = int64(len(snapshot.cells))
+	m.floor = snapshot.floor
+}
+
+func NewMemory(size int) *Memory {
+	return &Memory{
+		cells: make([]int64, size),
+		here:  0,
+	}
+}
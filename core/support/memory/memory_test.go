@@ -0,0 +1,130 @@
+package memory_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"tim/forth/core/support/memory"
+)
+
+func Test_MemoryStartsWithNothingAllotted(t *testing.T) {
+	m := memory.NewMemory(10)
+
+	if m.Here() != 0 {
+		t.Error(fmt.Sprintf("Expected HERE to be 0, instead got [%d]", m.Here()))
+	}
+
+	_, err := m.Fetch(0)
+	if !errors.As(err, new(*memory.InvalidAddressError)) {
+		t.Error(fmt.Sprintf("Expected an InvalidAddressError, instead got [%v]", err))
+	}
+}
+
+func Test_StoreThenFetch_returnsTheValue(t *testing.T) {
+	m := memory.NewMemory(10)
+	m.Allot(2)
+
+	m.Store(1, 42)
+	value, err := m.Fetch(1)
+
+	if err != nil || value != 42 {
+		t.Error(fmt.Sprintf("Expected [42], instead got [%d] with [%v]", value, err))
+	}
+}
+
+func Test_Comma_appendsCells(t *testing.T) {
+	m := memory.NewMemory(10)
+
+	m.Comma(7)
+	m.Comma(8)
+
+	if m.Here() != 2 {
+		t.Error(fmt.Sprintf("Expected HERE to be 2, instead got [%d]", m.Here()))
+	}
+	value, _ := m.Fetch(1)
+	if value != 8 {
+		t.Error(fmt.Sprintf("Expected [8], instead got [%d]", value))
+	}
+}
+
+func Test_OutOfBoundsAccess_isAnError(t *testing.T) {
+	m := memory.NewMemory(10)
+	m.Allot(3)
+
+	for _, address := range []int64{-1, 3, 100} {
+		err := m.Store(address, 1)
+		if !errors.As(err, new(*memory.InvalidAddressError)) {
+			t.Error(fmt.Sprintf("Expected an InvalidAddressError for [%d], instead got [%v]", address, err))
+		}
+	}
+
+	err := m.Allot(8)
+	if !errors.As(err, new(*memory.OutOfMemoryError)) {
+		t.Error(fmt.Sprintf("Expected an OutOfMemoryError, instead got [%v]", err))
+	}
+}
+
+func Test_Restore_returnsToTheSnapshot(t *testing.T) {
+	m := memory.NewMemory(10)
+	m.Comma(1)
+	snapshot := m.Snapshot()
+
+	m.Store(0, 5)
+	m.Comma(2)
+	m.Restore(snapshot)
+
+	value, _ := m.Fetch(0)
+	if value != 1 || m.Here() != 1 {
+		t.Error(fmt.Sprintf("Expected [1] with HERE at 1, instead got [%d] with HERE at [%d]", value, m.Here()))
+	}
+}
+
+func Test_Reserve_keepsCellsFromBeingGivenBack(t *testing.T) {
+	m := memory.NewMemory(10)
+	address, err := m.Reserve(10)
+	if err != nil || address != 0 {
+		t.Error(fmt.Sprintf("Expected the first reserved cell at [0], instead got [%d] with [%v]", address, err))
+	}
+	m.Allot(2)
+
+	err = m.Allot(-3)
+	if !errors.As(err, new(*memory.InvalidAddressError)) {
+		t.Error(fmt.Sprintf("Expected an InvalidAddressError, instead got [%v]", err))
+	}
+
+	err = m.Allot(-2)
+	if err != nil || m.Here() != 1 {
+		t.Error(fmt.Sprintf("Expected to give back down to [1], instead HERE is [%d] with [%v]", m.Here(), err))
+	}
+
+	value, _ := m.Fetch(address)
+	if value != 10 {
+		t.Error(fmt.Sprintf("Expected the reserved cell to hold [10], instead got [%d]", value))
+	}
+}
+
+func Test_Transient_isOutsideAllottedMemory(t *testing.T) {
+	m := memory.NewMemory(4)
+	m.Allot(4)
+
+	address := m.Transient([]int64{104, 105})
+	if address != 4 {
+		t.Error(fmt.Sprintf("Expected the transient area to start at [4], instead got [%d]", address))
+	}
+
+	value, err := m.Fetch(address + 1)
+	if err != nil || value != 105 {
+		t.Error(fmt.Sprintf("Expected [105], instead got [%d] with [%v]", value, err))
+	}
+
+	m.Allot(-4)
+	m.Transient([]int64{1})
+	_, err = m.Fetch(address + 1)
+	if !errors.As(err, new(*memory.InvalidAddressError)) {
+		t.Error(fmt.Sprintf("Expected the replaced text to be gone, instead got [%v]", err))
+	}
+	value, _ = m.Fetch(address)
+	if value != 1 {
+		t.Error(fmt.Sprintf("Expected [1], instead got [%d]", value))
+	}
+}
@@ -33,10 +33,29 @@ func (n Number) ValueOf() int64 {
 	return n.Value
 }
 
+// ExecutionToken refers to a word so it can be kept on the stack and run later
+// with EXECUTE. Its value is the token's id, which is what ends up in memory when
+// a token is stored there.
+type ExecutionToken struct {
+	Id   int64
+	Name string
+}
+
+func (t ExecutionToken) IsEmpty() bool {
+	return false
+}
+func (t ExecutionToken) ToString() string {
+	return fmt.Sprintf("xt:%s", t.Name)
+}
+func (t ExecutionToken) ValueOf() int64 {
+	return t.Id
+}
+
 type forthNode interface {
 	next() forthNode
 	isEmpty() bool
 	value() ForthItem
+	size() int
 }
 type emptyNode struct{}
 
@@ -49,10 +68,14 @@ func (e emptyNode) isEmpty() bool {
 func (e emptyNode) value() ForthItem {
 	return Empty{}
 }
+func (e emptyNode) size() int {
+	return 0
+}
 
 type populatedNode struct {
 	nextNode forthNode
 	item     ForthItem
+	depth    int
 }
 
 func (p *populatedNode) next() forthNode {
@@ -64,11 +87,15 @@ func (p *populatedNode) isEmpty() bool {
 func (p *populatedNode) value() ForthItem {
 	return p.item
 }
+func (p *populatedNode) size() int {
+	return p.depth
+}
 
 func newNode(next forthNode, item ForthItem) forthNode {
 	return &populatedNode{
 		nextNode: next,
 		item:     item,
+		depth:    next.size() + 1,
 	}
 }
 
@@ -92,6 +119,9 @@ func (stack *ForthStack) Pop() ForthItem {
 func (stack *ForthStack) Peek() ForthItem {
 	return stack.root.value()
 }
+func (stack *ForthStack) Size() int {
+	return stack.root.size()
+}
 func (stack *ForthStack) IsEmpty() bool {
 	return stack.root.isEmpty()
 }
@@ -111,6 +141,35 @@ func (stack *ForthStack) ToString() string {
 	return result
 }
 
+// StackSnapshot captures a ForthStack, since nodes are never changed once pushed
+// this is just a reference to the current top node.
+type StackSnapshot struct {
+	root forthNode
+}
+
+func (stack *ForthStack) Snapshot() StackSnapshot {
+	return StackSnapshot{root: stack.root}
+}
+func (stack *ForthStack) Restore(snapshot StackSnapshot) {
+	stack.root = snapshot.root
+}
+
+func (stack *ForthStack) Items() []ForthItem {
+	node := stack.root
+
+	result := []ForthItem{}
+	for {
+		if node.isEmpty() {
+			break
+		}
+
+		result = append(result, node.value())
+		node = node.next()
+	}
+
+	return result
+}
+
 func NewStack() *ForthStack {
 	return &ForthStack{root: emptyNode{}}
 }
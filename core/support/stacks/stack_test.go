@@ -108,3 +108,89 @@ func Test_StackToString(t *testing.T) {
 		t.Error("Stack should not be empty")
 	}
 }
+
+func Test_StackItems_areReturnedTopFirst(t *testing.T) {
+	stack := stacks.NewStack()
+
+	stack.Push(stacks.Number{Value: 1})
+	stack.Push(stacks.Number{Value: 2})
+	stack.Push(stacks.Number{Value: 3})
+
+	items := stack.Items()
+	if len(items) != 3 {
+		t.Error(fmt.Sprintf("Expected [%d] items, instead got [%d]", 3, len(items)))
+		return
+	}
+
+	for index, expected := range []int64{3, 2, 1} {
+		if items[index].ValueOf() != expected {
+			t.Error(fmt.Sprintf("Expected [%d] at [%d] but got [%d]", expected, index, items[index].ValueOf()))
+		}
+	}
+
+	if stack.IsEmpty() {
+		t.Error("Listing the items should not change the stack")
+	}
+}
+
+func Test_StackSize_tracksPushesAndPops(t *testing.T) {
+	stack := stacks.NewStack()
+
+	repeat(5, func() {
+		stack.Push(stacks.Number{Value: 1})
+	})
+	repeat(2, func() {
+		stack.Pop()
+	})
+
+	if stack.Size() != 3 {
+		t.Error(fmt.Sprintf("Expected a size of [%d], instead got [%d]", 3, stack.Size()))
+	}
+}
+
+func Test_StringStackItems_areReturnedTopFirst(t *testing.T) {
+	stack := stacks.NewStringStack()
+
+	stack.Push("a")
+	stack.Push("b")
+
+	items := stack.Items()
+	if len(items) != 2 || items[0] != "b" || items[1] != "a" {
+		t.Error(fmt.Sprintf("Expected [b a], instead got %v", items))
+	}
+	if stack.Size() != 2 {
+		t.Error("Listing the items should not change the stack")
+	}
+}
+
+func Test_StackRestore_returnsToTheSnapshot(t *testing.T) {
+	stack := stacks.NewStack()
+	stack.Push(stacks.Number{Value: 1})
+	stack.Push(stacks.Number{Value: 2})
+
+	snapshot := stack.Snapshot()
+
+	stack.Pop()
+	stack.Pop()
+	stack.Push(stacks.Number{Value: 3})
+
+	stack.Restore(snapshot)
+
+	if stack.ToString() != "[2][1]" {
+		t.Error(fmt.Sprintf("Expected [2][1] after restoring, instead got %s", stack.ToString()))
+	}
+	if stack.Size() != 2 {
+		t.Error(fmt.Sprintf("Expected a size of [%d], instead got [%d]", 2, stack.Size()))
+	}
+}
+
+func Test_ExecutionToken_keepsItsKindOnTheStack(t *testing.T) {
+	stack := stacks.NewStack()
+	stack.Push(stacks.ExecutionToken{Id: 3, Name: "square"})
+
+	item := stack.Pop()
+	token, isToken := item.(stacks.ExecutionToken)
+	if !isToken || token.ValueOf() != 3 || token.ToString() != "xt:square" {
+		t.Error(fmt.Sprintf("Expected the execution token for [square], instead got [%s]", item.ToString()))
+	}
+}
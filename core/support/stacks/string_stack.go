@@ -42,6 +42,7 @@ type StringStack interface {
 	Push(string)
 	Pop() string
 	Size() int
+	Items() []string
 	ToString() string
 }
 type Stack struct {
@@ -72,6 +73,21 @@ func (stack *Stack) Pop() string {
 func (stack *Stack) Size() int {
 	return stack.count
 }
+func (stack *Stack) Items() []string {
+	node := stack.root
+
+	result := []string{}
+	for {
+		if node.isEmpty() {
+			break
+		}
+
+		result = append(result, node.value())
+		node = node.next()
+	}
+
+	return result
+}
 func (stack *Stack) ToString() string {
 	node := stack.root
 
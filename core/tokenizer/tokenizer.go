@@ -0,0 +1,197 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type Token struct {
+	Value  string
+	Line   int
+	Column int
+}
+
+func (t Token) ToString() string {
+	return fmt.Sprintf("[%s] at %d:%d", t.Value, t.Line, t.Column)
+}
+
+type Tokenizer interface {
+	Next() (Token, bool)
+	// Offset is how far into the source the tokenizer has read, just past the
+	// last token Next returned.
+	Offset() int
+	// Text is the source between two offsets, exactly as it was written.
+	Text(start int, end int) string
+}
+
+type tokenizer struct {
+	source []rune
+	offset int
+	line   int
+	column int
+}
+
+func (t *tokenizer) advance() rune {
+	r := t.source[t.offset]
+	t.offset = t.offset + 1
+
+	if r == '\n' {
+		t.line = t.line + 1
+		t.column = 1
+	} else {
+		t.column = t.column + 1
+	}
+
+	return r
+}
+
+func (t *tokenizer) skipWhitespace() {
+	for {
+		if t.offset >= len(t.source) || !unicode.IsSpace(t.source[t.offset]) {
+			return
+		}
+
+		t.advance()
+	}
+}
+
+// skipPast drops everything up to and including end, or the rest of the source
+// when end never comes.
+func (t *tokenizer) skipPast(end rune) {
+	for {
+		if t.offset >= len(t.source) {
+			return
+		}
+
+		if t.advance() == end {
+			return
+		}
+	}
+}
+
+// Next returns the next whitespace delimited token, or false once the source is
+// exhausted. Comments, `( ... )` and `\` to the end of the line, are skipped.
+func (t *tokenizer) Next() (Token, bool) {
+	for {
+		token, found := t.next()
+		if !found {
+			return token, false
+		}
+
+		switch token.Value {
+		case "(":
+			t.skipPast(')')
+		case "\\":
+			t.skipPast('\n')
+		default:
+			return token, true
+		}
+	}
+}
+
+func (t *tokenizer) next() (Token, bool) {
+	t.skipWhitespace()
+
+	if t.offset >= len(t.source) {
+		return Token{}, false
+	}
+
+	token := Token{
+		Line:   t.line,
+		Column: t.column,
+	}
+
+	start := t.offset
+	for {
+		if t.offset >= len(t.source) || unicode.IsSpace(t.source[t.offset]) {
+			break
+		}
+
+		t.advance()
+	}
+	token.Value = string(t.source[start:t.offset])
+
+	if isStringWord(token.Value) {
+		token.Value = token.Value + " " + t.readString()
+	}
+
+	return token, true
+}
+
+// isStringWord is true for the words that take the text up to the next quote as
+// part of themselves, like ." Hello".
+func isStringWord(value string) bool {
+	switch strings.ToLower(value) {
+	case ".\"", "s\"", "c\"":
+		return true
+	}
+
+	return false
+}
+
+// Source turns a token's value back into the source text it was read from.
+func Source(value string) string {
+	word := strings.SplitN(value, " ", 2)[0]
+	if isStringWord(word) && word != value {
+		return value + "\""
+	}
+
+	return value
+}
+
+// readString returns the text up to the closing quote, or the rest of the source
+// if there is none, skipping the single space that ends the word before it.
+func (t *tokenizer) readString() string {
+	if t.offset < len(t.source) {
+		t.advance()
+	}
+
+	start := t.offset
+	for {
+		if t.offset >= len(t.source) {
+			return string(t.source[start:])
+		}
+
+		if t.source[t.offset] == '"' {
+			text := string(t.source[start:t.offset])
+			t.advance()
+			return text
+		}
+
+		t.advance()
+	}
+}
+
+func (t *tokenizer) Offset() int {
+	return t.offset
+}
+
+func (t *tokenizer) Text(start int, end int) string {
+	return string(t.source[start:end])
+}
+
+func NewTokenizer(source string) Tokenizer {
+	return &tokenizer{
+		source: []rune(source),
+		offset: 0,
+		line:   1,
+		column: 1,
+	}
+}
+
+func Tokenize(source string) []Token {
+	t := NewTokenizer(source)
+
+	result := []Token{}
+	for {
+		token, found := t.Next()
+		if !found {
+			break
+		}
+
+		result = append(result, token)
+	}
+
+	return result
+}
@@ -0,0 +1,123 @@
+package tokenizer_test
+
+import (
+	"fmt"
+	"testing"
+	"tim/forth/core/tokenizer"
+)
+
+func Test_EmptySource_hasNoTokens(t *testing.T) {
+	tokens := tokenizer.Tokenize(" \t\n  ")
+
+	if len(tokens) != 0 {
+		t.Error(fmt.Sprintf("Expected no tokens, instead got [%d]", len(tokens)))
+	}
+}
+
+func Test_SplitsOnAnyWhitespace(t *testing.T) {
+	tokens := tokenizer.Tokenize("1  2\t+\n\n  print ")
+
+	expected := []string{"1", "2", "+", "print"}
+	if len(tokens) != len(expected) {
+		t.Error(fmt.Sprintf("Expected [%d] tokens, instead got [%d]", len(expected), len(tokens)))
+		return
+	}
+
+	for index, value := range expected {
+		if tokens[index].Value != value {
+			t.Error(fmt.Sprintf("Expected [%s] but got [%s]", value, tokens[index].Value))
+		}
+	}
+}
+
+func Test_TracksLineAndColumn(t *testing.T) {
+	tokens := tokenizer.Tokenize(": cube\n  dup dup * * ;")
+
+	expected := []tokenizer.Token{
+		{Value: ":", Line: 1, Column: 1},
+		{Value: "cube", Line: 1, Column: 3},
+		{Value: "dup", Line: 2, Column: 3},
+		{Value: "dup", Line: 2, Column: 7},
+		{Value: "*", Line: 2, Column: 11},
+		{Value: "*", Line: 2, Column: 13},
+		{Value: ";", Line: 2, Column: 15},
+	}
+
+	if len(tokens) != len(expected) {
+		t.Error(fmt.Sprintf("Expected [%d] tokens, instead got [%d]", len(expected), len(tokens)))
+		return
+	}
+
+	for index, token := range expected {
+		if tokens[index] != token {
+			t.Error(fmt.Sprintf("Expected %s but got %s", token.ToString(), tokens[index].ToString()))
+		}
+	}
+}
+
+func Test_SkipsComments(t *testing.T) {
+	tokens := tokenizer.Tokenize(": cube ( n -- n^3 ) dup dup * * ; \\ cubes a number\n3 cube (not-a-comment\n( unterminated")
+
+	expected := []tokenizer.Token{
+		{Value: ":", Line: 1, Column: 1},
+		{Value: "cube", Line: 1, Column: 3},
+		{Value: "dup", Line: 1, Column: 21},
+		{Value: "dup", Line: 1, Column: 25},
+		{Value: "*", Line: 1, Column: 29},
+		{Value: "*", Line: 1, Column: 31},
+		{Value: ";", Line: 1, Column: 33},
+		{Value: "3", Line: 2, Column: 1},
+		{Value: "cube", Line: 2, Column: 3},
+		{Value: "(not-a-comment", Line: 2, Column: 8},
+	}
+	if len(tokens) != len(expected) {
+		t.Error(fmt.Sprintf("Expected [%d] tokens, instead got [%d]", len(expected), len(tokens)))
+		return
+	}
+
+	for index, token := range expected {
+		if tokens[index] != token {
+			t.Error(fmt.Sprintf("Expected %s but got %s", token.ToString(), tokens[index].ToString()))
+		}
+	}
+}
+
+func Test_QuotedTextIsPartOfTheStringWord(t *testing.T) {
+	tokens := tokenizer.Tokenize(`." Hello,  world" S" a b"type C"  x" ." unterminated`)
+
+	expected := []string{`." Hello,  world`, `S" a b`, "type", `C"  x`, `." unterminated`}
+	if len(tokens) != len(expected) {
+		t.Error(fmt.Sprintf("Expected [%d] tokens, instead got [%d]", len(expected), len(tokens)))
+		return
+	}
+
+	for index, value := range expected {
+		if tokens[index].Value != value {
+			t.Error(fmt.Sprintf("Expected [%s] but got [%s]", value, tokens[index].Value))
+		}
+	}
+
+	source := tokenizer.Source(tokens[0].Value)
+	if source != `." Hello,  world"` {
+		t.Error(fmt.Sprintf("Expected the source to be quoted again, instead got [%s]", source))
+	}
+}
+
+func Test_Text_keepsTheSourceBetweenOffsets(t *testing.T) {
+	source := ": cube ( n -- n³ ) dup dup * * ; \\ cubes\n3 cube"
+	tokens := tokenizer.NewTokenizer(source)
+
+	tokens.Next()
+	start := tokens.Offset()
+	for {
+		token, _ := tokens.Next()
+		if token.Value == ";" {
+			break
+		}
+	}
+
+	text := tokens.Text(start, tokens.Offset())
+	if text != " cube ( n -- n³ ) dup dup * * ;" {
+		t.Error(fmt.Sprintf("Expected the definition as it was written, instead got [%s]", text))
+	}
+}
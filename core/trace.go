@@ -0,0 +1,79 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"tim/forth/core/support/stacks"
+)
+
+type TraceEvent struct {
+	Word           string
+	Kind           WordKind
+	Stack          []stacks.ForthItem
+	ExecutionDepth int
+}
+
+// Tracer is told about every word the interpreter runs, once before it runs and
+// once after it has completed successfully.
+type Tracer interface {
+	Before(event TraceEvent)
+	After(event TraceEvent)
+}
+
+type writerTracer struct {
+	out io.Writer
+}
+
+func (t *writerTracer) Before(event TraceEvent) {
+	fmt.Fprintf(t.out, "> %s (%s) stack -> %s depth -> %d\n", event.Word, event.Kind.ToString(), stackString(event.Stack), event.ExecutionDepth)
+}
+func (t *writerTracer) After(event TraceEvent) {
+	fmt.Fprintf(t.out, "< %s (%s) stack -> %s depth -> %d\n", event.Word, event.Kind.ToString(), stackString(event.Stack), event.ExecutionDepth)
+}
+
+// NewWriterTracer writes one line per word before and after it runs.
+func NewWriterTracer(out io.Writer) Tracer {
+	return &writerTracer{
+		out: out,
+	}
+}
+
+// SetTracer registers the tracer and turns tracing on, passing nil turns it off.
+func (i *ForthInterpreter) SetTracer(tracer Tracer) {
+	i.tracer = tracer
+	i.tracing = tracer != nil
+}
+
+func (i *ForthInterpreter) trace(word string, kind WordKind, executionStack stacks.StringStack, notify func(Tracer, TraceEvent)) {
+	if !i.tracing {
+		return
+	}
+
+	notify(i.tracer, TraceEvent{
+		Word:           word,
+		Kind:           kind,
+		Stack:          i.stack.Items(),
+		ExecutionDepth: executionStack.Size(),
+	})
+}
+
+func traceBefore(t Tracer, event TraceEvent) {
+	t.Before(event)
+}
+func traceAfter(t Tracer, event TraceEvent) {
+	t.After(event)
+}
+
+func traceOn(i *ForthInterpreter) error {
+	if i.tracer == nil {
+		i.tracer = NewWriterTracer(i.context.Err)
+	}
+	i.tracing = true
+
+	return nil
+}
+func traceOff(i *ForthInterpreter) error {
+	i.tracing = false
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package core
+
+import (
+	"fmt"
+	"tim/forth/core/support/stacks"
+	"tim/forth/core/words"
+)
+
+func (i *ForthInterpreter) popValue() (int64, error) {
+	if i.stack.IsEmpty() {
+		return 0, words.NewUnderflowError()
+	}
+
+	return i.stack.Pop().ValueOf(), nil
+}
+
+func pushing(value int64) words.Word {
+	return func(executionContext *words.ExecutionContext, executionStack stacks.StringStack) error {
+		executionContext.Stack.Push(stacks.Number{Value: value})
+		return nil
+	}
+}
+
+// defineVariable allots a cell and defines a word that pushes its address.
+func defineVariable(i *ForthInterpreter, name string) error {
+	address := i.context.Memory.Here()
+	err := i.context.Memory.Comma(0)
+	if err != nil {
+		return err
+	}
+
+	i.define(name, fmt.Sprintf("variable %s", name), pushing(address))
+	i.words[name].image = func(i *ForthInterpreter, name string) (string, error) {
+		value, err := i.context.Memory.Fetch(address)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("variable %s %d %s !", name, value, name), nil
+	}
+
+	return nil
+}
+
+// defineConstant defines a word that pushes a value, a constant holding an
+// execution token is saved in images by the name of the token's word.
+func defineConstant(i *ForthInterpreter, name string) error {
+	if i.stack.IsEmpty() {
+		return words.NewUnderflowError()
+	}
+	item := i.stack.Pop()
+	value := item.ValueOf()
+
+	i.define(name, fmt.Sprintf("%d constant %s", value, name), pushing(value))
+	if token, isToken := item.(stacks.ExecutionToken); isToken {
+		i.words[name].image = func(i *ForthInterpreter, name string) (string, error) {
+			tokenName, err := i.savedTokenName(token)
+			if err != nil {
+				return "", err
+			}
+
+			return fmt.Sprintf("' %s constant %s", tokenName, name), nil
+		}
+	}
+
+	return nil
+}
+
+// defineValue is a constant that lives in memory, so TO can change it later.
+func defineValue(i *ForthInterpreter, name string) error {
+	value, err := i.popValue()
+	if err != nil {
+		return err
+	}
+
+	memory := i.context.Memory
+	address := memory.Here()
+	err = memory.Comma(value)
+	if err != nil {
+		return err
+	}
+
+	i.define(name, fmt.Sprintf("%d value %s", value, name), func(executionContext *words.ExecutionContext, executionStack stacks.StringStack) error {
+		current, err := memory.Fetch(address)
+		if err != nil {
+			return err
+		}
+
+		executionContext.Stack.Push(stacks.Number{Value: current})
+		return nil
+	})
+	entry := i.words[name]
+	entry.isValue = true
+	entry.dataField = address
+	entry.image = func(i *ForthInterpreter, name string) (string, error) {
+		current, err := memory.Fetch(address)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%d value %s", current, name), nil
+	}
+
+	return nil
+}
+
+func storeValue(i *ForthInterpreter, name string) error {
+	entry, found := i.lookup(name)
+	if !found || !entry.isValue {
+		return words.NewInvalidArgument(fmt.Sprintf("[%s] is not a VALUE", name))
+	}
+
+	value, err := i.popValue()
+	if err != nil {
+		return err
+	}
+
+	return i.context.Memory.Store(entry.dataField, value)
+}
+
+// create defines a word that pushes the address of the next free cell, whatever
+// is allotted after it is that word's data. Neither that data nor a DOES> part
+// can be rebuilt from source, so such words refuse to be saved in an image.
+func create(i *ForthInterpreter, name string) error {
+	address := i.context.Memory.Here()
+
+	i.define(name, fmt.Sprintf("create %s", name), pushing(address))
+	entry := i.words[name]
+	entry.dataField = address
+	entry.image = func(i *ForthInterpreter, name string) (string, error) {
+		return "", &UnsavableWordError{
+			Word:   name,
+			Reason: "it was made by CREATE, its data and any DOES> behaviour are not kept in images",
+		}
+	}
+	i.lastCreated = name
+
+	return nil
+}
+
+// does makes the last CREATEd word run the named DOES> part of a defining word
+// after pushing its data field address. The entry is replaced rather than
+// changed so snapshots taken before still see the old behaviour.
+func does(i *ForthInterpreter, behaviour string) error {
+	created, found := i.words[i.lastCreated]
+	if i.lastCreated == "" || !found {
+		return words.NewInvalidArgument("[does>] needs a word made by CREATE")
+	}
+
+	entry := *created
+	entry.run = func(executionContext *words.ExecutionContext, executionStack stacks.StringStack) error {
+		executionContext.Stack.Push(stacks.Number{Value: entry.dataField})
+		executionStack.Push(behaviour)
+
+		return nil
+	}
+	i.words[i.lastCreated] = &entry
+
+	return nil
+}
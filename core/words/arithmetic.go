@@ -0,0 +1,133 @@
+package words
+
+import (
+	"fmt"
+	"math/big"
+)
+
+type Division int
+
+const (
+	// SymmetricDivision rounds quotients towards zero, so the remainder takes the
+	// sign of the dividend: -7 2 / is -3 and -7 2 MOD is -1.
+	SymmetricDivision Division = iota
+	// FlooredDivision rounds quotients down, so the remainder takes the sign of
+	// the divisor: -7 2 / is -4 and -7 2 MOD is 1.
+	FlooredDivision
+)
+
+type DivisionByZeroError struct{}
+
+func (d DivisionByZeroError) Error() string {
+	return "Division by zero"
+}
+
+// divide returns the quotient and remainder of n / d rounded as ctx.Division says.
+func (ctx *ExecutionContext) divide(n int64, d int64) (int64, int64, error) {
+	if d == 0 {
+		return 0, 0, DivisionByZeroError{}
+	}
+
+	quotient := n / d
+	remainder := n % d
+	if ctx.Division == FlooredDivision && remainder != 0 && (remainder < 0) != (d < 0) {
+		quotient = quotient - 1
+		remainder = remainder + d
+	}
+
+	return quotient, remainder, nil
+}
+
+// scale is */ and */MOD, n * m / d without the product overflowing on the way.
+func (ctx *ExecutionContext) scale(n int64, m int64, d int64) (int64, int64, error) {
+	if d == 0 {
+		return 0, 0, DivisionByZeroError{}
+	}
+
+	product := new(big.Int).Mul(big.NewInt(n), big.NewInt(m))
+	divisor := big.NewInt(d)
+	quotient, remainder := new(big.Int).QuoRem(product, divisor, new(big.Int))
+	if ctx.Division == FlooredDivision && remainder.Sign() != 0 && remainder.Sign() != divisor.Sign() {
+		quotient.Sub(quotient, big.NewInt(1))
+		remainder.Add(remainder, divisor)
+	}
+
+	if !quotient.IsInt64() {
+		return 0, 0, NewInvalidArgument(fmt.Sprintf("%d %d %d */ does not fit in a cell", n, m, d))
+	}
+
+	return quotient.Int64(), remainder.Int64(), nil
+}
+
+// arithmeticWords take their operands in the standard order in both modes, the
+// item below the top by the top, so 7 2 / is 3 and 7 2 mod is 1 even in legacy
+// mode where - subtracts the other way round and 7 2 - is -5.
+func arithmeticWords() map[string]NativeWord {
+	result := make(map[string]NativeWord)
+
+	result["/"] = func(ctx *ExecutionContext) error {
+		return binaryOperation(func(a int64, b int64) (int64, error) {
+			quotient, _, err := ctx.divide(b, a)
+			return quotient, err
+		})(ctx)
+	}
+	result["mod"] = func(ctx *ExecutionContext) error {
+		return binaryOperation(func(a int64, b int64) (int64, error) {
+			_, remainder, err := ctx.divide(b, a)
+			return remainder, err
+		})(ctx)
+	}
+	result["/mod"] = func(ctx *ExecutionContext) error {
+		return operation(2, func(args []int64) ([]int64, error) {
+			quotient, remainder, err := ctx.divide(args[0], args[1])
+			return []int64{remainder, quotient}, err
+		})(ctx)
+	}
+	result["*/"] = func(ctx *ExecutionContext) error {
+		return operation(3, func(args []int64) ([]int64, error) {
+			quotient, _, err := ctx.scale(args[0], args[1], args[2])
+			return []int64{quotient}, err
+		})(ctx)
+	}
+	result["*/mod"] = func(ctx *ExecutionContext) error {
+		return operation(3, func(args []int64) ([]int64, error) {
+			quotient, remainder, err := ctx.scale(args[0], args[1], args[2])
+			return []int64{remainder, quotient}, err
+		})(ctx)
+	}
+	result["min"] = binaryOperation(func(a int64, b int64) (int64, error) {
+		if a < b {
+			return a, nil
+		}
+		return b, nil
+	})
+	result["max"] = binaryOperation(func(a int64, b int64) (int64, error) {
+		if a > b {
+			return a, nil
+		}
+		return b, nil
+	})
+	result["negate"] = uinaryOperation(func(a int64) (int64, error) {
+		return -a, nil
+	})
+	result["abs"] = uinaryOperation(func(a int64) (int64, error) {
+		if a < 0 {
+			return -a, nil
+		}
+		return a, nil
+	})
+	result["1+"] = uinaryOperation(func(a int64) (int64, error) {
+		return a + 1, nil
+	})
+	result["1-"] = uinaryOperation(func(a int64) (int64, error) {
+		return a - 1, nil
+	})
+	result["2*"] = uinaryOperation(func(a int64) (int64, error) {
+		return a << 1, nil
+	})
+	result["2/"] = uinaryOperation(func(a int64) (int64, error) {
+		return a >> 1, nil
+	})
+
+	return result
+}
@@ -0,0 +1,106 @@
+package words
+
+import (
+	"io"
+	"tim/forth/core/logging"
+	"tim/forth/core/support/memory"
+	"tim/forth/core/support/stacks"
+)
+
+type Semantics int
+
+const (
+	// LegacySemantics is how this interpreter has always behaved: 0 is true and
+	// 1 is false, comparisons and IF leave their arguments on the stack, . peeks
+	// and - subtracts the second item from the top one.
+	LegacySemantics Semantics = iota
+	// StandardSemantics follows ANS Forth: -1 is true and 0 is false, comparisons,
+	// IF and . consume what they use, and - subtracts the top item from the second.
+	StandardSemantics
+)
+
+type ExecutionContext struct {
+	Stack  *stacks.ForthStack
+	In     InputSource
+	Out    io.Writer
+	Err    io.Writer
+	Logger logging.Logger
+	Loops  *LoopStack
+	Memory *memory.Memory
+	// BaseAddress is the cell behind BASE.
+	BaseAddress int64
+	// Return is the return stack, it is separate from the loops so a DO loop
+	// can use I while values are kept on the return stack.
+	Return *stacks.ForthStack
+
+	Semantics Semantics
+	Division  Division
+
+	// Step is called by natives that do a lot of work between each piece of it,
+	// so that a long run still stops at the interpreter's limits.
+	Step func(word string) error
+}
+
+// DefaultMemorySize is the number of cells available to a new context.
+const DefaultMemorySize = 65536
+
+type NativeWord func(*ExecutionContext) error
+
+// Word is anything that can run from the execution stack, it may push further
+// words onto the execution stack for them to run next.
+type Word func(*ExecutionContext, stacks.StringStack) error
+
+func (ctx *ExecutionContext) Standard() bool {
+	return ctx.Semantics == StandardSemantics
+}
+
+// Flag is the value pushed for a boolean result.
+func (ctx *ExecutionContext) Flag(b bool) int64 {
+	if ctx.Standard() {
+		if b {
+			return -1
+		}
+		return 0
+	}
+
+	return toStackBoolean(b)
+}
+
+// IsTrue reports whether a value taken from the stack is a true flag, in legacy
+// mode only 0 is true while in standard mode anything other than 0 is.
+func (ctx *ExecutionContext) IsTrue(value int64) bool {
+	if ctx.Standard() {
+		return value != 0
+	}
+
+	return value == toStackBoolean(true)
+}
+
+func NewExecutionContext(stack *stacks.ForthStack, out io.Writer, err io.Writer, logger logging.Logger) *ExecutionContext {
+	ctx, memoryErr := NewExecutionContextWithMemory(stack, out, err, logger, memory.NewMemory(DefaultMemorySize))
+	if memoryErr != nil {
+		// The default memory always has room for BASE.
+		panic(memoryErr)
+	}
+
+	return ctx
+}
+
+// NewExecutionContextWithMemory is NewExecutionContext using the given memory,
+// which fails when it has no room for BASE.
+func NewExecutionContextWithMemory(stack *stacks.ForthStack, out io.Writer, err io.Writer, logger logging.Logger, m *memory.Memory) (*ExecutionContext, error) {
+	ctx := &ExecutionContext{
+		Stack:  stack,
+		In:     NewStringInput(""),
+		Out:    out,
+		Err:    err,
+		Logger: logger,
+		Loops:  NewLoopStack(),
+		Return: stacks.NewStack(),
+		Step: func(string) error {
+			return nil
+		},
+	}
+
+	return ctx, ctx.SetMemory(m)
+}
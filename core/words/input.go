@@ -0,0 +1,59 @@
+package words
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// InputSource is where KEY reads characters from.
+type InputSource interface {
+	// Key waits for the next character, returning io.EOF once there are no more.
+	Key() (int64, error)
+	// KeyAvailable reports whether Key can return without waiting.
+	KeyAvailable() bool
+}
+
+type readerInput struct {
+	reader *bufio.Reader
+	// remaining is set for readers that know how much is left to read, such as
+	// strings.Reader, when reading them never waits.
+	remaining interface{ Len() int }
+}
+
+func (r *readerInput) Key() (int64, error) {
+	b, err := r.reader.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(b), nil
+}
+func (r *readerInput) KeyAvailable() bool {
+	if r.reader.Buffered() > 0 {
+		return true
+	}
+
+	return r.remaining != nil && r.remaining.Len() > 0
+}
+
+// NewReaderInput reads keys from r, a *bufio.Reader is used as it is so that it
+// can be shared with something else reading lines from the same input.
+func NewReaderInput(r io.Reader) InputSource {
+	reader, buffered := r.(*bufio.Reader)
+	if !buffered {
+		reader = bufio.NewReader(r)
+	}
+
+	remaining, _ := r.(interface{ Len() int })
+
+	return &readerInput{
+		reader:    reader,
+		remaining: remaining,
+	}
+}
+
+// NewStringInput is canned input, handy for tests.
+func NewStringInput(s string) InputSource {
+	return NewReaderInput(strings.NewReader(s))
+}
@@ -0,0 +1,70 @@
+package words
+
+import (
+	"strings"
+	"tim/forth/core/support/stacks"
+)
+
+// blanks is the most SPACES writes at once, so a large count never needs a
+// buffer of its own.
+var blanks = []byte(strings.Repeat(" ", 64))
+
+func write(ctx *ExecutionContext, s string) error {
+	_, err := ctx.Out.Write([]byte(s))
+	return err
+}
+
+// ioWords write characters to the context's output and read them from its input.
+func ioWords() map[string]NativeWord {
+	result := make(map[string]NativeWord)
+
+	result["emit"] = func(ctx *ExecutionContext) error {
+		return withItems(ctx.Stack, 1, func(items []stacks.ForthItem) error {
+			_, err := ctx.Out.Write([]byte{byte(items[0].ValueOf())})
+			return err
+		})
+	}
+	result["cr"] = func(ctx *ExecutionContext) error {
+		return write(ctx, "\n")
+	}
+	result["space"] = func(ctx *ExecutionContext) error {
+		return write(ctx, " ")
+	}
+	result["spaces"] = func(ctx *ExecutionContext) error {
+		return withItems(ctx.Stack, 1, func(items []stacks.ForthItem) error {
+			for n := items[0].ValueOf(); n > 0; n -= int64(len(blanks)) {
+				chunk := blanks
+				if n < int64(len(chunk)) {
+					chunk = chunk[:n]
+				}
+
+				err := ctx.Step("spaces")
+				if err != nil {
+					return err
+				}
+
+				_, err = ctx.Out.Write(chunk)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+	result["key"] = func(ctx *ExecutionContext) error {
+		key, err := ctx.In.Key()
+		if err != nil {
+			return err
+		}
+
+		pushNumber(ctx, key)
+		return nil
+	}
+	result["key?"] = func(ctx *ExecutionContext) error {
+		pushNumber(ctx, ctx.Flag(ctx.In.KeyAvailable()))
+		return nil
+	}
+
+	return result
+}
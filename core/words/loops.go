@@ -0,0 +1,130 @@
+package words
+
+import (
+	"fmt"
+	"tim/forth/core/support/stacks"
+)
+
+type ControlFlowError struct {
+	message string
+}
+
+func (c *ControlFlowError) Error() string {
+	return fmt.Sprintf("Control flow error: %s", c.message)
+}
+func NewControlFlowError(message string) error {
+	return &ControlFlowError{
+		message: message,
+	}
+}
+
+// LoopFrame is the state of one running DO loop.
+type LoopFrame struct {
+	Id    string
+	Index int64
+	Limit int64
+	// Base is the size of the execution stack before the loop pushed its body,
+	// unwinding to it removes everything left of the loop.
+	Base int
+}
+
+type LoopStack struct {
+	frames []*LoopFrame
+}
+
+func (l *LoopStack) Push(frame *LoopFrame) {
+	l.frames = append(l.frames, frame)
+}
+func (l *LoopStack) Pop() *LoopFrame {
+	if len(l.frames) == 0 {
+		return nil
+	}
+
+	last := len(l.frames) - 1
+	frame := l.frames[last]
+	l.frames = l.frames[:last]
+
+	return frame
+}
+
+// Peek returns the frame depth loops out from the innermost one, so 0 is the
+// loop I refers to and 1 the loop J refers to.
+func (l *LoopStack) Peek(depth int) (*LoopFrame, error) {
+	index := len(l.frames) - 1 - depth
+	if index < 0 {
+		return nil, NewControlFlowError(fmt.Sprintf("expected at least [%d] loops to be running, found [%d]", depth+1, len(l.frames)))
+	}
+
+	return l.frames[index], nil
+}
+func (l *LoopStack) Size() int {
+	return len(l.frames)
+}
+
+// Truncate drops frames until only size remain.
+func (l *LoopStack) Truncate(size int) {
+	if size < len(l.frames) {
+		l.frames = l.frames[:size]
+	}
+}
+
+func NewLoopStack() *LoopStack {
+	return &LoopStack{
+		frames: []*LoopFrame{},
+	}
+}
+
+// Unwind pops words off the execution stack until only size remain.
+func Unwind(executionStack stacks.StringStack, size int) {
+	for {
+		if executionStack.Size() <= size {
+			return
+		}
+
+		executionStack.Pop()
+	}
+}
+
+func loopIndex(depth int) Word {
+	return func(ctx *ExecutionContext, executionStack stacks.StringStack) error {
+		frame, err := ctx.Loops.Peek(depth)
+		if err != nil {
+			return err
+		}
+
+		ctx.Stack.Push(stacks.Number{Value: frame.Index})
+
+		return nil
+	}
+}
+
+// ControlWords are the words that work with the loops the compiler builds.
+func ControlWords() map[string]Word {
+	control := make(map[string]Word)
+
+	control["i"] = loopIndex(0)
+	control["j"] = loopIndex(1)
+	control["leave"] = func(ctx *ExecutionContext, executionStack stacks.StringStack) error {
+		frame, err := ctx.Loops.Peek(0)
+		if err != nil {
+			return err
+		}
+
+		Unwind(executionStack, frame.Base)
+		ctx.Loops.Pop()
+
+		return nil
+	}
+	control["unloop"] = func(ctx *ExecutionContext, executionStack stacks.StringStack) error {
+		_, err := ctx.Loops.Peek(0)
+		if err != nil {
+			return err
+		}
+
+		ctx.Loops.Pop()
+
+		return nil
+	}
+
+	return control
+}
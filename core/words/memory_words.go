@@ -0,0 +1,68 @@
+package words
+
+import "tim/forth/core/support/stacks"
+
+func pushNumber(ctx *ExecutionContext, value int64) {
+	ctx.Stack.Push(stacks.Number{Value: value})
+}
+
+// memoryWords read and write the cells of the context's memory, addresses are
+// cell indexes so `HERE 1 +` is the cell after HERE.
+func memoryWords() map[string]NativeWord {
+	result := make(map[string]NativeWord)
+
+	result["@"] = func(ctx *ExecutionContext) error {
+		return uinaryOperation(func(address int64) (int64, error) {
+			return ctx.Memory.Fetch(address)
+		})(ctx)
+	}
+	result["!"] = func(ctx *ExecutionContext) error {
+		return withItems(ctx.Stack, 2, func(items []stacks.ForthItem) error {
+			return ctx.Memory.Store(items[0].ValueOf(), items[1].ValueOf())
+		})
+	}
+	result["+!"] = func(ctx *ExecutionContext) error {
+		return withItems(ctx.Stack, 2, func(items []stacks.ForthItem) error {
+			address := items[0].ValueOf()
+			value, err := ctx.Memory.Fetch(address)
+			if err != nil {
+				return err
+			}
+
+			return ctx.Memory.Store(address, value+items[1].ValueOf())
+		})
+	}
+	result["c@"] = func(ctx *ExecutionContext) error {
+		return uinaryOperation(func(address int64) (int64, error) {
+			value, err := ctx.Memory.Fetch(address)
+			return value & 0xff, err
+		})(ctx)
+	}
+	result["c!"] = func(ctx *ExecutionContext) error {
+		return withItems(ctx.Stack, 2, func(items []stacks.ForthItem) error {
+			return ctx.Memory.Store(items[0].ValueOf(), items[1].ValueOf()&0xff)
+		})
+	}
+	result["cells"] = uinaryOperation(func(n int64) (int64, error) {
+		return n, nil
+	})
+	result["cell+"] = uinaryOperation(func(address int64) (int64, error) {
+		return address + 1, nil
+	})
+	result["here"] = func(ctx *ExecutionContext) error {
+		pushNumber(ctx, ctx.Memory.Here())
+		return nil
+	}
+	result["allot"] = func(ctx *ExecutionContext) error {
+		return withItems(ctx.Stack, 1, func(items []stacks.ForthItem) error {
+			return ctx.Memory.Allot(items[0].ValueOf())
+		})
+	}
+	result[","] = func(ctx *ExecutionContext) error {
+		return withItems(ctx.Stack, 1, func(items []stacks.ForthItem) error {
+			return ctx.Memory.Comma(items[0].ValueOf())
+		})
+	}
+
+	return result
+}
@@ -27,45 +27,43 @@ func NewInvalidArgument(message string) error {
 	}
 }
 
-func uinaryOperation(op func(int64) (int64, error)) func(*stacks.ForthStack) error {
-	return func(stack *stacks.ForthStack) error {
-		if stack.IsEmpty() {
-			return NewUnderflowError()
-		}
-
-		item := stack.Pop()
-
-		result, err := op(item.ValueOf())
-		if err != nil {
-			return err
-		}
-		stack.Push(stacks.Number{Value: result})
+// operation pops n values and pushes whatever op returns, args and results are
+// both in stack order with the deepest item first.
+func operation(n int, op func([]int64) ([]int64, error)) NativeWord {
+	return func(ctx *ExecutionContext) error {
+		return withItems(ctx.Stack, n, func(items []stacks.ForthItem) error {
+			args := make([]int64, n)
+			for index, item := range items {
+				args[n-1-index] = item.ValueOf()
+			}
+
+			results, err := op(args)
+			if err != nil {
+				return err
+			}
+
+			for _, result := range results {
+				ctx.Stack.Push(stacks.Number{Value: result})
+			}
 
-		return nil
+			return nil
+		})
 	}
 }
-func binaryOperation(op func(int64, int64) (int64, error)) func(*stacks.ForthStack) error {
-	return func(stack *stacks.ForthStack) error {
-		if stack.IsEmpty() {
-			return NewUnderflowError()
-		}
 
-		item1 := stack.Pop()
-
-		if stack.IsEmpty() {
-			return NewUnderflowError()
-		}
-
-		item2 := stack.Pop()
-
-		result, err := op(item1.ValueOf(), item2.ValueOf())
-		if err != nil {
-			return err
-		}
-		stack.Push(stacks.Number{Value: result})
+func uinaryOperation(op func(int64) (int64, error)) NativeWord {
+	return operation(1, func(args []int64) ([]int64, error) {
+		result, err := op(args[0])
+		return []int64{result}, err
+	})
+}
 
-		return nil
-	}
+// binaryOperation calls op with the top of the stack first.
+func binaryOperation(op func(int64, int64) (int64, error)) NativeWord {
+	return operation(2, func(args []int64) ([]int64, error) {
+		result, err := op(args[1], args[0])
+		return []int64{result}, err
+	})
 }
 
 func toStackBoolean(b bool) int64 {
@@ -79,8 +77,9 @@ func toStackBoolean(b bool) int64 {
 	return boolF
 }
 
-func comparisonOperation(op func(int64, int64) bool) func(*stacks.ForthStack) error {
-	return func(stack *stacks.ForthStack) error {
+func comparisonOperation(op func(int64, int64) bool) NativeWord {
+	return func(ctx *ExecutionContext) error {
+		stack := ctx.Stack
 		if stack.IsEmpty() {
 			return NewUnderflowError()
 		}
@@ -93,6 +92,13 @@ func comparisonOperation(op func(int64, int64) bool) func(*stacks.ForthStack) er
 
 		item2 := stack.Pop()
 
+		if ctx.Standard() {
+			result := op(item2.ValueOf(), item1.ValueOf())
+			stack.Push(stacks.Number{Value: ctx.Flag(result)})
+
+			return nil
+		}
+
 		result := op(item1.ValueOf(), item2.ValueOf())
 
 		stackBoolean := toStackBoolean(result)
@@ -130,10 +136,11 @@ func withItems(stack *stacks.ForthStack, n int, ifItems func([]stacks.ForthItem)
 	return NewUnderflowError()
 }
 
-func NativeWords() map[string]func(*stacks.ForthStack) error {
+func NativeWords() map[string]NativeWord {
 
-	predefined := make(map[string]func(*stacks.ForthStack) error)
-	predefined["dup"] = func(stack *stacks.ForthStack) error {
+	predefined := make(map[string]NativeWord)
+	predefined["dup"] = func(ctx *ExecutionContext) error {
+		stack := ctx.Stack
 		if stack.IsEmpty() {
 			return NewUnderflowError()
 		}
@@ -144,7 +151,8 @@ func NativeWords() map[string]func(*stacks.ForthStack) error {
 
 		return nil
 	}
-	predefined["drop"] = func(stack *stacks.ForthStack) error {
+	predefined["drop"] = func(ctx *ExecutionContext) error {
+		stack := ctx.Stack
 		if stack.IsEmpty() {
 			return NewUnderflowError()
 		}
@@ -153,16 +161,13 @@ func NativeWords() map[string]func(*stacks.ForthStack) error {
 
 		return nil
 	}
-	predefined["print"] = func(stack *stacks.ForthStack) error {
-		fmt.Printf("H -> %s <- T\n", stack.ToString())
+	predefined["print"] = func(ctx *ExecutionContext) error {
+		fmt.Fprintf(ctx.Out, "H -> %s <- T\n", ctx.Stack.ToString())
 
 		return nil
 	}
-	predefined["."] = func(stack *stacks.ForthStack) error {
-		fmt.Println(stack.Peek().ToString())
-		return nil
-	}
-	predefined["flip"] = func(stack *stacks.ForthStack) error {
+	predefined["flip"] = func(ctx *ExecutionContext) error {
+		stack := ctx.Stack
 		return withItems(stack, 2, func(items []stacks.ForthItem) error {
 			v1 := items[0]
 			v2 := items[1]
@@ -173,7 +178,9 @@ func NativeWords() map[string]func(*stacks.ForthStack) error {
 			return nil
 		})
 	}
-	predefined["rotate"] = func(stack *stacks.ForthStack) error {
+	predefined["swap"] = predefined["flip"]
+	predefined["rotate"] = func(ctx *ExecutionContext) error {
+		stack := ctx.Stack
 		return withItems(stack, 3, func(items []stacks.ForthItem) error {
 			first := items[0]
 			second := items[1]
@@ -192,9 +199,15 @@ func NativeWords() map[string]func(*stacks.ForthStack) error {
 	predefined["*"] = binaryOperation(func(a int64, b int64) (int64, error) {
 		return a * b, nil
 	})
-	predefined["-"] = binaryOperation(func(a int64, b int64) (int64, error) {
-		return a - b, nil
-	})
+	predefined["-"] = func(ctx *ExecutionContext) error {
+		return binaryOperation(func(a int64, b int64) (int64, error) {
+			if ctx.Standard() {
+				return b - a, nil
+			}
+
+			return a - b, nil
+		})(ctx)
+	}
 
 	predefined[">"] = comparisonOperation(func(a int64, b int64) bool {
 		return (a > b)
@@ -214,19 +227,51 @@ func NativeWords() map[string]func(*stacks.ForthStack) error {
 	predefined["!="] = comparisonOperation(func(a int64, b int64) bool {
 		return (a != b)
 	})
-	predefined["branch"] = uinaryOperation(func(a int64) (int64, error) {
-		if a == 1 {
-			fmt.Println("True!!!")
-			return a, nil
+	predefined["="] = predefined["=="]
+	predefined["<>"] = predefined["!="]
+	// 0= is exactly `0 =`, so it leaves its argument behind in legacy mode too.
+	predefined["0="] = func(ctx *ExecutionContext) error {
+		if ctx.Stack.IsEmpty() {
+			return NewUnderflowError()
 		}
 
-		if a == 0 {
-			fmt.Println("False!!!")
-			return a, nil
-		}
+		pushNumber(ctx, 0)
+		return predefined["="](ctx)
+	}
+	predefined["branch"] = func(ctx *ExecutionContext) error {
+		return uinaryOperation(func(a int64) (int64, error) {
+			if a == ctx.Flag(true) {
+				ctx.Logger.Trace("branch -> true")
+				return a, nil
+			}
+
+			if a == ctx.Flag(false) {
+				ctx.Logger.Trace("branch -> false")
+				return a, nil
+			}
+
+			return 0, NewInvalidArgument(fmt.Sprintf("The provided value [%d] is not acceptable as a boolean (must be %d or %d)", a, ctx.Flag(true), ctx.Flag(false)))
+		})(ctx)
+	}
 
-		return 0, NewInvalidArgument(fmt.Sprintf("The provided value [%d] is not acceptable as a boolean (must be 0 or 1)", a))
-	})
+	for key, value := range memoryWords() {
+		predefined[key] = value
+	}
+	for key, value := range returnWords() {
+		predefined[key] = value
+	}
+	for key, value := range stringWords() {
+		predefined[key] = value
+	}
+	for key, value := range ioWords() {
+		predefined[key] = value
+	}
+	for key, value := range numberWords() {
+		predefined[key] = value
+	}
+	for key, value := range arithmeticWords() {
+		predefined[key] = value
+	}
 
 	return predefined
 }
@@ -0,0 +1,134 @@
+package words
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"tim/forth/core/support/memory"
+	"tim/forth/core/support/stacks"
+)
+
+// prefixes give a literal a base of its own whatever BASE is, so $ff, #255 and
+// %11111111 are always the same number.
+var prefixes = map[byte]int{
+	'$': 16,
+	'#': 10,
+	'%': 2,
+}
+
+// ParseNumber reads a literal in the given base, with a base prefix or as a
+// character in quotes like 'a'.
+func ParseNumber(s string, base int) (int64, bool) {
+	runes := []rune(s)
+	if len(runes) == 3 && runes[0] == '\'' && runes[2] == '\'' {
+		return int64(runes[1]), true
+	}
+
+	if len(s) > 1 {
+		prefixed, found := prefixes[s[0]]
+		if found {
+			s = s[1:]
+			base = prefixed
+		}
+	}
+
+	if base < 2 || base > 36 {
+		return 0, false
+	}
+
+	value, err := strconv.ParseInt(s, base, 64)
+	return value, err == nil
+}
+
+// SetMemory gives the context a new memory, starting it with the reserved BASE
+// cell.
+func (ctx *ExecutionContext) SetMemory(m *memory.Memory) error {
+	ctx.Memory = m
+
+	address, err := m.Reserve(10)
+	ctx.BaseAddress = address
+
+	return err
+}
+
+// Radix is the base numbers are currently read and printed in.
+func (ctx *ExecutionContext) Radix() (int, error) {
+	base, err := ctx.Memory.Fetch(ctx.BaseAddress)
+	if err != nil {
+		return 0, err
+	}
+	if base < 2 || base > 36 {
+		return 0, NewInvalidArgument(fmt.Sprintf("BASE [%d] is not between 2 and 36", base))
+	}
+
+	return int(base), nil
+}
+
+// Format writes a number in the current base, anything else as it is.
+func (ctx *ExecutionContext) Format(item stacks.ForthItem, unsigned bool) (string, error) {
+	number, isNumber := item.(stacks.Number)
+	if !isNumber {
+		return item.ToString(), nil
+	}
+
+	base, err := ctx.Radix()
+	if err != nil {
+		return "", err
+	}
+
+	if unsigned {
+		return strings.ToUpper(strconv.FormatUint(uint64(number.Value), base)), nil
+	}
+
+	return strings.ToUpper(strconv.FormatInt(number.Value, base)), nil
+}
+
+func setBase(base int64) NativeWord {
+	return func(ctx *ExecutionContext) error {
+		return ctx.Memory.Store(ctx.BaseAddress, base)
+	}
+}
+
+// printNumber is . and U., popping and following the number with a space in
+// standard mode and peeking and ending the line in legacy mode.
+func printNumber(unsigned bool) NativeWord {
+	return func(ctx *ExecutionContext) error {
+		if ctx.Standard() {
+			if ctx.Stack.IsEmpty() {
+				return NewUnderflowError()
+			}
+
+			text, err := ctx.Format(ctx.Stack.Pop(), unsigned)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(ctx.Out, "%s ", text)
+			return nil
+		}
+
+		text, err := ctx.Format(ctx.Stack.Peek(), unsigned)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(ctx.Out, text)
+		return nil
+	}
+}
+
+func numberWords() map[string]NativeWord {
+	result := make(map[string]NativeWord)
+
+	result["."] = printNumber(false)
+	result["u."] = printNumber(true)
+	result["base"] = func(ctx *ExecutionContext) error {
+		pushNumber(ctx, ctx.BaseAddress)
+		return nil
+	}
+	result["decimal"] = setBase(10)
+	result["hex"] = setBase(16)
+	result["binary"] = setBase(2)
+
+	return result
+}
@@ -0,0 +1,56 @@
+package words
+
+import "tim/forth/core/support/stacks"
+
+type ReturnUnderflowError struct{}
+
+func (u ReturnUnderflowError) Error() string {
+	return "Return Stack Underflow"
+}
+
+// moveItems pops n items from one stack and pushes them onto the other keeping
+// their order, so `1 2 2>R 2R>` leaves 1 2.
+func moveItems(from *stacks.ForthStack, to *stacks.ForthStack, n int, underflow error) error {
+	if from.Size() < n {
+		return underflow
+	}
+
+	items := make([]stacks.ForthItem, n)
+	for index := range items {
+		items[index] = from.Pop()
+	}
+	for index := n - 1; index >= 0; index-- {
+		to.Push(items[index])
+	}
+
+	return nil
+}
+
+// returnWords move values between the data stack and the return stack, which
+// holds temporary values for the length of a definition.
+func returnWords() map[string]NativeWord {
+	result := make(map[string]NativeWord)
+
+	result[">r"] = func(ctx *ExecutionContext) error {
+		return moveItems(ctx.Stack, ctx.Return, 1, NewUnderflowError())
+	}
+	result["r>"] = func(ctx *ExecutionContext) error {
+		return moveItems(ctx.Return, ctx.Stack, 1, ReturnUnderflowError{})
+	}
+	result["r@"] = func(ctx *ExecutionContext) error {
+		if ctx.Return.IsEmpty() {
+			return ReturnUnderflowError{}
+		}
+
+		ctx.Stack.Push(ctx.Return.Peek())
+		return nil
+	}
+	result["2>r"] = func(ctx *ExecutionContext) error {
+		return moveItems(ctx.Stack, ctx.Return, 2, NewUnderflowError())
+	}
+	result["2r>"] = func(ctx *ExecutionContext) error {
+		return moveItems(ctx.Return, ctx.Stack, 2, ReturnUnderflowError{})
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+package words
+
+import (
+	"fmt"
+	"tim/forth/core/support/stacks"
+)
+
+// stringWords work with text kept in memory one byte per cell, either as an
+// address and a length or as a counted string whose first cell is its length.
+func stringWords() map[string]NativeWord {
+	result := make(map[string]NativeWord)
+
+	result["type"] = func(ctx *ExecutionContext) error {
+		return withItems(ctx.Stack, 2, func(items []stacks.ForthItem) error {
+			length := items[0].ValueOf()
+			address := items[1].ValueOf()
+			if length < 0 {
+				return NewInvalidArgument(fmt.Sprintf("The length [%d] given to TYPE can not be negative", length))
+			}
+
+			// Both ends must be allotted before anything is read, which also
+			// bounds the buffer by the size of memory.
+			if length > 0 {
+				_, err := ctx.Memory.Fetch(address)
+				if err != nil {
+					return err
+				}
+				_, err = ctx.Memory.Fetch(address + length - 1)
+				if err != nil {
+					return err
+				}
+			}
+
+			text := make([]byte, 0, length)
+			for offset := int64(0); offset < length; offset++ {
+				value, err := ctx.Memory.Fetch(address + offset)
+				if err != nil {
+					return err
+				}
+
+				text = append(text, byte(value))
+			}
+
+			_, err := ctx.Out.Write(text)
+			return err
+		})
+	}
+	result["count"] = func(ctx *ExecutionContext) error {
+		return withItems(ctx.Stack, 1, func(items []stacks.ForthItem) error {
+			address := items[0].ValueOf()
+			length, err := ctx.Memory.Fetch(address)
+			if err != nil {
+				return err
+			}
+
+			pushNumber(ctx, address+1)
+			pushNumber(ctx, length)
+
+			return nil
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"fmt"
+	"tim/forth/core/support/stacks"
+	"tim/forth/core/words"
+)
+
+// executionTokens maps the id in an execution token to a hidden dictionary name
+// for the word it was taken from, so redefining the word later does not change
+// what the token runs.
+type executionTokens struct {
+	names map[int64]string
+	ids   map[*dictionaryEntry]int64
+	next  int64
+}
+
+func newExecutionTokens() *executionTokens {
+	return &executionTokens{
+		names: make(map[int64]string),
+		ids:   make(map[*dictionaryEntry]int64),
+		next:  1,
+	}
+}
+
+func (i *ForthInterpreter) tokenFor(name string) (stacks.ExecutionToken, error) {
+	entry, found := i.lookup(name)
+	if !found {
+		return stacks.ExecutionToken{}, &UndefinedWordError{
+			Word:  name,
+			Stack: i.stack.Items(),
+		}
+	}
+
+	tokens := i.tokens
+	id, found := tokens.ids[entry]
+	if !found {
+		id = tokens.next
+		tokens.next = tokens.next + 1
+		tokens.ids[entry] = id
+		tokens.names[id] = fmt.Sprintf("%s:xt%d", name, id)
+	}
+
+	// The hidden entry is a copy without its source so images leave it out, it
+	// may also have to be put back after a Restore took it away.
+	if _, found := i.words[tokens.names[id]]; !found {
+		hidden := *entry
+		hidden.source = ""
+		i.words[tokens.names[id]] = &hidden
+	}
+
+	return stacks.ExecutionToken{
+		Id:   id,
+		Name: name,
+	}, nil
+}
+
+// savedTokenName is the name an image can tick to get a token for the same word
+// back. That only works while the name still means the word the token was taken
+// from and the word is built in or saved in the image too.
+func (i *ForthInterpreter) savedTokenName(token stacks.ExecutionToken) (string, error) {
+	entry, found := i.lookup(token.Name)
+	if found && i.tokens.ids[entry] == token.Id && (entry.order == 0 || entry.source != "") {
+		return token.Name, nil
+	}
+
+	return "", &UnsavableWordError{
+		Word:   token.Name,
+		Reason: fmt.Sprintf("execution token [%d] no longer refers to a word an image can name", token.Id),
+	}
+}
+
+func (i *ForthInterpreter) pushToken(name string) error {
+	token, err := i.tokenFor(name)
+	if err != nil {
+		return err
+	}
+
+	i.stack.Push(token)
+	return nil
+}
+
+// executeToken is EXECUTE, it pushes the word behind the token onto the
+// execution stack so it runs like any other word.
+func (i *ForthInterpreter) executeToken(executionContext *words.ExecutionContext, executionStack stacks.StringStack) error {
+	if i.stack.IsEmpty() {
+		return words.NewUnderflowError()
+	}
+
+	id := i.stack.Pop().ValueOf()
+	name, found := i.tokens.names[id]
+	if !found {
+		return words.NewInvalidArgument(fmt.Sprintf("[%d] is not an execution token", id))
+	}
+	if _, found := i.words[name]; !found {
+		return words.NewInvalidArgument(fmt.Sprintf("the word behind execution token [%d] no longer exists", id))
+	}
+
+	executionStack.Push(name)
+	return nil
+}
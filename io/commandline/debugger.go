@@ -0,0 +1,72 @@
+package io
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"tim/forth/core"
+)
+
+type commandLineDebugger struct {
+	reader *bufio.Reader
+	out    io.Writer
+}
+
+func (d *commandLineDebugger) printStack(pause core.DebugPause) {
+	result := ""
+	for _, item := range pause.Stack {
+		result = result + fmt.Sprintf("[%s]", item.ToString())
+	}
+	fmt.Fprintf(d.out, "stack   H -> %s <- T\n", result)
+}
+func (d *commandLineDebugger) printPending(pause core.DebugPause) {
+	result := ""
+	for _, word := range pause.ExecutionStack {
+		result = result + fmt.Sprintf("[%s]", word)
+	}
+	fmt.Fprintf(d.out, "pending H -> %s <- T\n", result)
+}
+
+func (d *commandLineDebugger) Paused(pause core.DebugPause) core.DebugCommand {
+	reason := "step"
+	if pause.Breakpoint {
+		reason = "breakpoint"
+	}
+	fmt.Fprintf(d.out, "Paused at [%s] (%s, %s)\n", pause.Word, pause.Kind.ToString(), reason)
+	d.printStack(pause)
+	d.printPending(pause)
+
+	for {
+		fmt.Fprint(d.out, "debug> ")
+		text, err := d.reader.ReadString('\n')
+		if err != nil {
+			return core.DebugContinue
+		}
+
+		switch strings.TrimSpace(text) {
+		case "", "s", "step":
+			return core.DebugStep
+		case "o", "over":
+			return core.DebugStepOver
+		case "c", "continue":
+			return core.DebugContinue
+		case "q", "abort":
+			return core.DebugAbort
+		case "stack":
+			d.printStack(pause)
+		case "pending":
+			d.printPending(pause)
+		default:
+			fmt.Fprintln(d.out, "step (s), over (o), continue (c), abort (q), stack, pending")
+		}
+	}
+}
+
+// NewDebugController pauses by prompting for debugger commands on the command line.
+func NewDebugController(reader *bufio.Reader, out io.Writer) core.DebugController {
+	return &commandLineDebugger{
+		reader: reader,
+		out:    out,
+	}
+}
@@ -3,13 +3,11 @@ package io
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"strings"
 	"tim/forth/core"
 )
 
-func CommandLineSource(commandHandler core.ForthCommandHandler) {
-	reader := bufio.NewReader(os.Stdin)
+func CommandLineSource(commandHandler core.ForthCommandHandler, reader *bufio.Reader) {
 	fmt.Println("Simple Shell")
 	fmt.Println("----------------")
 